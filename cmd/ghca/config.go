@@ -0,0 +1,192 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+
+	"github.com/sderosiaux/git-contributor-insights/pkg/atomicfile"
+	"github.com/sderosiaux/git-contributor-insights/pkg/config"
+	"github.com/sderosiaux/git-contributor-insights/pkg/gitdm"
+)
+
+var (
+	validateStrict     bool
+	importAffiliations []string
+	importDomainMaps   []string
+	importOutput       string
+
+	configCmd = &cobra.Command{
+		Use:   "config",
+		Short: "Inspect and maintain vendor configuration files",
+	}
+
+	configValidateCmd = &cobra.Command{
+		Use:   "validate <config-file>",
+		Short: "Check a vendor configuration for mistakes and overlapping rules",
+		Long: `Check a vendor configuration file for unknown keys, domains or company
+substrings claimed by several vendors, vendors without rules and malformed
+domains. Exits with a non-zero status when errors are found.
+
+Overlapping rules are resolved by the vendors' 'priority' (higher wins); an
+overlap between vendors of equal priority is reported as an error.
+
+Examples:
+  ghca config validate kafka_vendors.yaml
+  ghca config validate vendors.yaml --strict`,
+		Args: cobra.ExactArgs(1),
+		Run:  runConfigValidate,
+	}
+
+	configImportCmd = &cobra.Command{
+		Use:   "import",
+		Short: "Convert gitdm / CNCF affiliation files into a vendor configuration",
+		Long: `Convert affiliation data maintained in gitdm format into a vendor
+configuration: each company becomes a vendor, domain-map entries become its
+domains and developers_affiliations.txt entries become time-bounded identities.
+
+Both flags can be repeated; later files replace earlier entries for the same
+developer or domain. "Independent" developers are assigned to community and
+unknown affiliations (NotFound, (Unknown), ...) are skipped.
+
+Examples:
+  ghca config import --affiliations developers_affiliations.txt -o cncf.yaml
+  ghca config import --affiliations developers_affiliations1.txt \
+    --affiliations developers_affiliations2.txt --domain-map domain-map`,
+		Args: cobra.NoArgs,
+		Run:  runConfigImport,
+	}
+)
+
+func init() {
+	configValidateCmd.Flags().BoolVar(&validateStrict, "strict", false, "Treat warnings as errors")
+
+	configImportCmd.Flags().StringArrayVar(&importAffiliations, "affiliations", nil, "developers_affiliations.txt file (repeatable)")
+	configImportCmd.Flags().StringArrayVar(&importDomainMaps, "domain-map", nil, "gitdm domain-map file (repeatable)")
+	configImportCmd.Flags().StringVarP(&importOutput, "output", "o", "", "Write the YAML config to this file (default: stdout)")
+
+	configCmd.AddCommand(configValidateCmd)
+	configCmd.AddCommand(configImportCmd)
+	rootCmd.AddCommand(configCmd)
+}
+
+func runConfigValidate(cmd *cobra.Command, args []string) {
+	path := args[0]
+
+	issues, err := config.ValidateFile(path)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error loading config: %v\n", err)
+		os.Exit(1)
+	}
+
+	errorCount, warningCount := 0, 0
+	for _, issue := range issues {
+		label := dimStyle.Render("info   ")
+		switch issue.Severity {
+		case config.SeverityError:
+			label = redStyle.Render("error  ")
+			errorCount++
+		case config.SeverityWarning:
+			label = yellowStyle.Render("warning")
+			warningCount++
+		}
+
+		if issue.Vendor != "" {
+			fmt.Printf("%s  %s: %s\n", label, issue.Vendor, issue.Message)
+		} else {
+			fmt.Printf("%s  %s\n", label, issue.Message)
+		}
+	}
+
+	if len(issues) > 0 {
+		fmt.Println()
+	}
+
+	failed := errorCount > 0 || (validateStrict && warningCount > 0)
+	summary := fmt.Sprintf("%s: %d errors, %d warnings", path, errorCount, warningCount)
+	if failed {
+		fmt.Println(redStyle.Render("✗") + " " + summary)
+		os.Exit(1)
+	}
+	fmt.Println(greenStyle.Render("✓") + " " + summary)
+}
+
+func runConfigImport(cmd *cobra.Command, args []string) {
+	if len(importAffiliations) == 0 && len(importDomainMaps) == 0 {
+		fmt.Fprintln(os.Stderr, "Error: pass at least one --affiliations or --domain-map file")
+		os.Exit(1)
+	}
+
+	importer := gitdm.NewImporter()
+	for _, path := range importDomainMaps {
+		if err := importer.ReadDomainMapFile(path); err != nil {
+			fmt.Fprintf(os.Stderr, "Error reading domain map: %v\n", err)
+			os.Exit(1)
+		}
+	}
+	for _, path := range importAffiliations {
+		if err := importer.ReadAffiliationsFile(path); err != nil {
+			fmt.Fprintf(os.Stderr, "Error reading affiliations: %v\n", err)
+			os.Exit(1)
+		}
+	}
+
+	cfg, err := importer.Config()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error converting affiliations: %v\n", err)
+		os.Exit(1)
+	}
+
+	// Refuse to write a config that ghca would fail to load, e.g. identities
+	// whose from date is after their until date from out-of-order gitdm ranges
+	invalid := 0
+	for _, issue := range cfg.Validate() {
+		if issue.Severity == config.SeverityError {
+			message := issue.Message
+			if issue.Vendor != "" {
+				message = issue.Vendor + ": " + message
+			}
+			fmt.Fprintln(os.Stderr, redStyle.Render("error")+"  "+message)
+			invalid++
+		}
+	}
+	if invalid > 0 {
+		fmt.Fprintf(os.Stderr, "Error: imported config has %d errors; fix the affiliation data and import again\n", invalid)
+		os.Exit(1)
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("# Imported from gitdm affiliation data by ghca config import\n")
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(cfg); err != nil {
+		fmt.Fprintf(os.Stderr, "Error encoding config: %v\n", err)
+		os.Exit(1)
+	}
+	data := buf.Bytes()
+
+	for _, warning := range importer.Warnings {
+		fmt.Fprintln(os.Stderr, yellowStyle.Render("warning")+"  "+warning)
+	}
+
+	summary := fmt.Sprintf("Imported %d vendors and %d identities", len(cfg.Vendors), len(cfg.Identities))
+	if importOutput == "" {
+		os.Stdout.Write(data)
+		fmt.Fprintln(os.Stderr, greenStyle.Render("✓")+" "+summary)
+		return
+	}
+
+	err = atomicfile.Write(importOutput, func(w io.Writer) error {
+		_, err := w.Write(data)
+		return err
+	})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error writing config: %v\n", err)
+		os.Exit(1)
+	}
+	fmt.Println(greenStyle.Render("✓") + " " + summary + " into " + importOutput)
+}
@@ -0,0 +1,146 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/sderosiaux/git-contributor-insights/pkg/analyzer"
+	"github.com/sderosiaux/git-contributor-insights/pkg/tui"
+)
+
+var (
+	contributorVendors  []string
+	contributorMinCount int
+	contributorMinLines int
+	contributorSort     string
+	contributorAsc      bool
+	contributorLimit    int
+	contributorPeriods  string
+	showPeriods         bool
+
+	contributorsCmd = &cobra.Command{
+		Use:   "contributors [repo-path]",
+		Short: "List contributors with their classification and activity",
+		Long: `List every contributor identity with its vendor classification, commit count,
+lines changed, first/last activity and the periods in which it was active.
+
+Examples:
+  ghca contributors /path/to/kafka --config vendors.yaml
+  ghca contributors ./repo --vendor confluent --vendor aiven --sort lines
+  ghca contributors ./repo --since 2024-01-01 --min-commits 5 --sort name --asc
+  ghca contributors ./repo --vendor community --periods month --show-periods`,
+		Args: cobra.ExactArgs(1),
+		Run:  runContributors,
+	}
+)
+
+func init() {
+	contributorsCmd.Flags().StringArrayVarP(&configPaths, "config", "c", nil, "Vendor configuration YAML file (repeatable; later files extend earlier ones)")
+	contributorsCmd.Flags().StringVar(&profilesPath, "profiles", "", "Profile file (.json or .csv) mapping emails/logins to companies")
+	addPersonalDomainFlags(contributorsCmd)
+	contributorsCmd.Flags().StringVar(&sinceDate, "since", "", "Only include commits since this date (YYYY-MM-DD)")
+	contributorsCmd.Flags().StringVar(&untilDate, "until", "", "Only include commits until this date (YYYY-MM-DD)")
+	contributorsCmd.Flags().IntVarP(&workers, "workers", "w", 8, "Number of concurrent workers (default: 8)")
+	contributorsCmd.Flags().StringSliceVar(&contributorVendors, "vendor", nil, "Only show contributors classified as this vendor (repeatable)")
+	contributorsCmd.Flags().IntVar(&contributorMinCount, "min-commits", 0, "Only show contributors with at least this many commits")
+	contributorsCmd.Flags().IntVar(&contributorMinLines, "min-lines", 0, "Only show contributors with at least this many lines changed")
+	contributorsCmd.Flags().StringVarP(&contributorSort, "sort", "s", "commits", "Sort by: commits, lines, additions, periods, first, last, name")
+	contributorsCmd.Flags().BoolVar(&contributorAsc, "asc", false, "Sort in ascending order (default: ascending for name, descending otherwise; --asc=false forces descending)")
+	contributorsCmd.Flags().IntVarP(&contributorLimit, "limit", "n", 0, "Show at most N contributors (0 shows all)")
+	contributorsCmd.Flags().StringVar(&contributorPeriods, "periods", "quarter", "Granularity of active periods: year, quarter, month, week")
+	contributorsCmd.Flags().BoolVar(&showPeriods, "show-periods", false, "List each contributor's active periods")
+
+	rootCmd.AddCommand(contributorsCmd)
+}
+
+func runContributors(cmd *cobra.Command, args []string) {
+	repoPath := args[0]
+
+	validSorts := map[string]bool{
+		"commits": true, "lines": true, "additions": true, "periods": true,
+		"first": true, "last": true, "name": true,
+	}
+	if !validSorts[contributorSort] {
+		fmt.Fprintf(os.Stderr, "Invalid sort field: %s (must be: commits, lines, additions, periods, first, last, name)\n", contributorSort)
+		os.Exit(1)
+	}
+	if !isValidBreakdown(contributorPeriods) {
+		fmt.Fprintf(os.Stderr, "Invalid periods type: %s (must be: year, quarter, month, week)\n", contributorPeriods)
+		os.Exit(1)
+	}
+
+	printBanner()
+
+	cfg := loadConfig()
+	since, until := parseDateFilters()
+
+	fetcher, _ := openRepository(repoPath)
+	commits := fetchCommits(fetcher, since, until)
+
+	if len(commits) == 0 {
+		fmt.Fprintln(status, yellowStyle.Render("No commits found in the specified date range"))
+		return
+	}
+
+	applyProfiles(commits)
+
+	profiles := analyzer.AnalyzeContributors(commits, cfg, contributorPeriods)
+	total := len(profiles)
+
+	profiles = analyzer.FilterContributors(profiles, analyzer.ContributorFilter{
+		Vendors:    contributorVendors,
+		MinCommits: contributorMinCount,
+		MinLines:   contributorMinLines,
+	})
+	// Names read A→Z by default, numbers and dates largest/latest first
+	ascending := contributorSort == "name"
+	if cmd.Flags().Changed("asc") {
+		ascending = contributorAsc
+	}
+	analyzer.SortContributorProfiles(profiles, contributorSort, !ascending)
+
+	matched := len(profiles)
+	if contributorLimit > 0 && len(profiles) > contributorLimit {
+		profiles = profiles[:contributorLimit]
+	}
+
+	fmt.Fprintf(status, "%s %s of %s contributors match\n",
+		greenStyle.Render("✓"),
+		analyzer.FormatNumber(matched),
+		analyzer.FormatNumber(total),
+	)
+	fmt.Fprintln(status)
+
+	fmt.Fprintf(status, "%-44s %-18s %8s  %12s  %13s  %-10s  %-10s  %s\n",
+		"Contributor", "Category", "Commits", "Lines Added", "Lines Deleted", "First", "Last", "Active")
+	fmt.Fprintln(status, strings.Repeat("─", 136))
+
+	for _, p := range profiles {
+		fmt.Fprintf(status, "%-44s %-18s %8s  %12s  %13s  %-10s  %-10s  %s\n",
+			tui.Truncate(p.Identity(), 44),
+			tui.Truncate(p.Category, 18),
+			analyzer.FormatNumber(p.Commits),
+			"+"+analyzer.FormatNumber(p.Additions),
+			"-"+analyzer.FormatNumber(p.Deletions),
+			p.FirstCommit.Format("2006-01-02"),
+			p.LastCommit.Format("2006-01-02"),
+			fmt.Sprintf("%d %s", len(p.ActivePeriods), periodUnit(contributorPeriods, len(p.ActivePeriods))),
+		)
+		if showPeriods {
+			fmt.Fprintln(status, dimStyle.Render("  "+joinStrings(p.ActivePeriods, ", ")))
+		}
+	}
+
+	printFooter()
+}
+
+// periodUnit returns the (pluralized) unit name for a breakdown type
+func periodUnit(breakdownType string, n int) string {
+	if n == 1 {
+		return breakdownType
+	}
+	return breakdownType + "s"
+}
@@ -0,0 +1,182 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/spf13/cobra"
+
+	"github.com/sderosiaux/git-contributor-insights/pkg/analyzer"
+	"github.com/sderosiaux/git-contributor-insights/pkg/config"
+	"github.com/sderosiaux/git-contributor-insights/pkg/tui"
+)
+
+var (
+	domainsUnmappedOnly bool
+	domainsHighlight    int
+	domainsLimit        int
+	domainsEmitYAML     bool
+
+	domainsCmd = &cobra.Command{
+		Use:   "domains [repo-path]",
+		Short: "Report email domains and how the vendor config maps them",
+		Long: `List every author email domain with commit and contributor counts and the
+vendor (if any) the current config maps it to. High-volume unmapped domains are
+highlighted, and --emit-yaml prints a vendors.yaml skeleton for them.
+
+Examples:
+  ghca domains /path/to/kafka
+  ghca domains /path/to/kafka --config vendors.yaml --unmapped
+  ghca domains ./repo --config vendors.yaml --highlight 25 --emit-yaml`,
+		Args: cobra.ExactArgs(1),
+		Run:  runDomains,
+	}
+)
+
+func init() {
+	domainsCmd.Flags().StringArrayVarP(&configPaths, "config", "c", nil, "Vendor configuration YAML file (repeatable; later files extend earlier ones)")
+	addPersonalDomainFlags(domainsCmd)
+	domainsCmd.Flags().StringVar(&sinceDate, "since", "", "Only include commits since this date (YYYY-MM-DD)")
+	domainsCmd.Flags().StringVar(&untilDate, "until", "", "Only include commits until this date (YYYY-MM-DD)")
+	domainsCmd.Flags().IntVarP(&workers, "workers", "w", 8, "Number of concurrent workers (default: 8)")
+	domainsCmd.Flags().BoolVar(&domainsUnmappedOnly, "unmapped", false, "Only show domains no vendor rule matches")
+	domainsCmd.Flags().IntVar(&domainsHighlight, "highlight", 10, "Highlight unmapped domains with at least this many commits")
+	domainsCmd.Flags().IntVarP(&domainsLimit, "limit", "n", 0, "Show at most N domains (0 shows all)")
+	domainsCmd.Flags().BoolVar(&domainsEmitYAML, "emit-yaml", false, "Print a vendors.yaml skeleton for highlighted unmapped domains")
+
+	rootCmd.AddCommand(domainsCmd)
+}
+
+func runDomains(cmd *cobra.Command, args []string) {
+	repoPath := args[0]
+
+	printBanner()
+
+	cfg := loadConfig()
+	since, until := parseDateFilters()
+
+	fetcher, _ := openRepository(repoPath)
+	commits := fetchCommits(fetcher, since, until)
+
+	if len(commits) == 0 {
+		fmt.Println(yellowStyle.Render("No commits found in the specified date range"))
+		return
+	}
+
+	domains := analyzer.AnalyzeDomains(commits, cfg)
+
+	mappedCommits, unmappedCommits := 0, 0
+	candidates := make([]*analyzer.DomainStats, 0)
+	for _, d := range domains {
+		switch d.Status {
+		case analyzer.DomainMapped:
+			mappedCommits += d.Commits
+		case analyzer.DomainUnmapped:
+			unmappedCommits += d.Commits
+			if d.Commits >= domainsHighlight {
+				candidates = append(candidates, d)
+			}
+		}
+	}
+
+	fmt.Printf("%s Found %s email domains (%s commits mapped to vendors, %s unmapped)\n",
+		greenStyle.Render("✓"),
+		analyzer.FormatNumber(len(domains)),
+		analyzer.FormatNumber(mappedCommits),
+		analyzer.FormatNumber(unmappedCommits),
+	)
+	fmt.Println()
+
+	highlightStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("11")).Bold(true)
+
+	fmt.Printf("%-36s %10s  %10s  %14s  %-10s  %s\n",
+		"Domain", "Commits", "% Commits", "Contributors", "Status", "Vendor")
+	fmt.Println(strings.Repeat("─", 100))
+
+	shown := 0
+	for _, d := range domains {
+		if domainsUnmappedOnly && d.Status != analyzer.DomainUnmapped {
+			continue
+		}
+		if domainsLimit > 0 && shown >= domainsLimit {
+			break
+		}
+		shown++
+
+		row := fmt.Sprintf("%-36s %10s  %9.1f%%  %14s  %-10s  %s",
+			tui.Truncate(d.Domain, 36),
+			analyzer.FormatNumber(d.Commits),
+			float64(d.Commits)/float64(len(commits))*100,
+			analyzer.FormatNumber(d.ContributorCount()),
+			d.Status,
+			d.Vendor,
+		)
+
+		switch {
+		case d.Status == analyzer.DomainUnmapped && d.Commits >= domainsHighlight:
+			fmt.Println(highlightStyle.Render(row))
+		case d.Status == analyzer.DomainPersonal || d.Status == analyzer.DomainCategory:
+			fmt.Println(dimStyle.Render(row))
+		default:
+			fmt.Println(row)
+		}
+	}
+
+	if len(candidates) > 0 {
+		fmt.Println()
+		fmt.Printf("%s %d unmapped domains have %d+ commits\n",
+			yellowStyle.Render("ℹ"), len(candidates), domainsHighlight)
+	}
+
+	if skeleton := renderVendorSkeleton(candidates, cfg); domainsEmitYAML && skeleton != "" {
+		fmt.Println()
+		fmt.Println(dimStyle.Render("# Suggested vendors.yaml entries (review names before use)"))
+		fmt.Print(skeleton)
+	}
+
+	printFooter()
+}
+
+// renderVendorSkeleton renders a vendors.yaml block for the given domains,
+// grouping domains that share a suggested vendor name. Commits without an
+// email domain cannot be claimed by a vendor and are left out.
+func renderVendorSkeleton(domains []*analyzer.DomainStats, cfg *config.Config) string {
+	var out strings.Builder
+
+	order := make([]string, 0)
+	grouped := make(map[string][]*analyzer.DomainStats)
+	for _, d := range domains {
+		if d.Domain == analyzer.NoDomain {
+			continue
+		}
+		name := analyzer.SuggestVendorName(d.Domain)
+		if _, ok := grouped[name]; !ok {
+			order = append(order, name)
+		}
+		grouped[name] = append(grouped[name], d)
+	}
+
+	if len(order) == 0 {
+		return ""
+	}
+
+	out.WriteString("vendors:\n")
+	for _, name := range order {
+		commits := 0
+		for _, d := range grouped[name] {
+			commits += d.Commits
+		}
+		note := fmt.Sprintf("%d commits", commits)
+		if _, exists := cfg.Vendors[name]; exists {
+			note += ", merge into existing vendor"
+		}
+		out.WriteString(fmt.Sprintf("  %s:  # %s\n", name, note))
+		out.WriteString("    domains:\n")
+		for _, d := range grouped[name] {
+			out.WriteString(fmt.Sprintf("      - %s\n", d.Domain))
+		}
+	}
+
+	return out.String()
+}
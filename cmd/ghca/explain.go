@@ -0,0 +1,218 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/sderosiaux/git-contributor-insights/pkg/analyzer"
+	"github.com/sderosiaux/git-contributor-insights/pkg/config"
+	"github.com/sderosiaux/git-contributor-insights/pkg/profiles"
+	"github.com/sderosiaux/git-contributor-insights/pkg/types"
+)
+
+var (
+	explainCompany     string
+	explainShowCommits bool
+
+	explainCmd = &cobra.Command{
+		Use:   "explain <email-or-name> [repo-path]",
+		Short: "Explain why a contributor is classified the way it is",
+		Long: `Show which rule classified a contributor, which other rules would also have
+matched, and (when a repository is given) the commits involved.
+
+Without a repository the argument is classified as an email address (if it
+contains '@') or a name. With a repository, every identity whose email or name
+matches the argument is explained, split into date ranges when time-bounded
+identities change the classification.
+
+Examples:
+  ghca explain jane@eu.confluent.io --config vendors.yaml
+  ghca explain "Jane Doe" /path/to/kafka --config vendors.yaml --show-commits
+  ghca explain jane@example.com --config vendors.yaml --company "Confluent, Inc."`,
+		Args: cobra.RangeArgs(1, 2),
+		Run:  runExplain,
+	}
+)
+
+func init() {
+	explainCmd.Flags().StringArrayVarP(&configPaths, "config", "c", nil, "Vendor configuration YAML file (repeatable; later files extend earlier ones)")
+	explainCmd.Flags().StringVar(&profilesPath, "profiles", "", "Profile file (.json or .csv) mapping emails/logins to companies")
+	addPersonalDomainFlags(explainCmd)
+	explainCmd.Flags().StringVar(&sinceDate, "since", "", "Only include commits since this date (YYYY-MM-DD)")
+	explainCmd.Flags().StringVar(&untilDate, "until", "", "Only include commits until this date (YYYY-MM-DD)")
+	explainCmd.Flags().IntVarP(&workers, "workers", "w", 8, "Number of concurrent workers (default: 8)")
+	explainCmd.Flags().StringVar(&explainCompany, "company", "", "GitHub company to classify with (overrides profile data)")
+	explainCmd.Flags().BoolVar(&explainShowCommits, "show-commits", false, "List every matching commit")
+
+	rootCmd.AddCommand(explainCmd)
+}
+
+func runExplain(cmd *cobra.Command, args []string) {
+	query := args[0]
+
+	printBanner()
+
+	cfg := loadConfig()
+
+	if len(args) == 1 {
+		who := config.Contributor{Company: explainCompany}
+		if strings.Contains(query, "@") {
+			who.Email = query
+		} else {
+			who.Name = query
+		}
+
+		if who.Company == "" && who.Email != "" && profilesPath != "" {
+			store, err := profiles.Load(profilesPath)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error loading profiles: %v\n", err)
+				os.Exit(1)
+			}
+			who.Company = store.Company(who.Email)
+		}
+
+		fmt.Println(cyanStyle.Bold(true).Render(query))
+		printExplanation(cfg.Explain(who), "  ")
+		fmt.Println()
+		printFooter()
+		return
+	}
+
+	since, until := parseDateFilters()
+	fetcher, _ := openRepository(args[1])
+	commits := fetchCommits(fetcher, since, until)
+	applyProfiles(commits)
+
+	// Group matching commits by identity
+	identities := make(map[string][]*types.CommitData)
+	for _, commit := range commits {
+		if strings.EqualFold(commit.AuthorEmail, query) || strings.EqualFold(commit.AuthorName, query) {
+			id := commit.ContributorID()
+			identities[id] = append(identities[id], commit)
+		}
+	}
+
+	if len(identities) == 0 {
+		fmt.Println(yellowStyle.Render("No commits found for " + query))
+		return
+	}
+
+	ids := make([]string, 0, len(identities))
+	for id := range identities {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	for _, id := range ids {
+		explainIdentity(cfg, identities[id])
+	}
+
+	printFooter()
+}
+
+// explainIdentity explains one identity's commits, splitting them into
+// consecutive date ranges that were classified by the same rule
+func explainIdentity(cfg *config.Config, commits []*types.CommitData) {
+	sorted := make([]*types.CommitData, len(commits))
+	copy(sorted, commits)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Date.Before(sorted[j].Date) })
+
+	fmt.Println(cyanStyle.Bold(true).Render(types.FormatIdentity(sorted[0].AuthorName, sorted[0].AuthorEmail)))
+
+	type segment struct {
+		exp     *config.Explanation
+		commits []*types.CommitData
+	}
+	segments := make([]*segment, 0)
+
+	for _, c := range sorted {
+		company := c.AuthorCompany
+		if explainCompany != "" {
+			company = explainCompany
+		}
+
+		exp := cfg.Explain(config.Contributor{
+			Name:    c.AuthorName,
+			Email:   c.AuthorEmail,
+			Company: company,
+			Date:    c.Date,
+		})
+
+		if n := len(segments); n > 0 && segments[n-1].exp.Matched == exp.Matched {
+			segments[n-1].commits = append(segments[n-1].commits, c)
+			continue
+		}
+		segments = append(segments, &segment{exp: exp, commits: []*types.CommitData{c}})
+	}
+
+	for _, seg := range segments {
+		first, last := seg.commits[0], seg.commits[len(seg.commits)-1]
+		fmt.Printf("  %s → %s  %s commits\n",
+			first.Date.Format("2006-01-02"),
+			last.Date.Format("2006-01-02"),
+			analyzer.FormatNumber(len(seg.commits)),
+		)
+		printExplanation(seg.exp, "    ")
+
+		if explainShowCommits {
+			for _, c := range seg.commits {
+				fmt.Println(dimStyle.Render(fmt.Sprintf("      %s  %s  %s",
+					c.Date.Format("2006-01-02"), c.SHA[:10], firstLine(c.Message))))
+			}
+		}
+	}
+
+	fmt.Println()
+}
+
+// printExplanation prints the category, deciding rule and alternatives
+func printExplanation(exp *config.Explanation, indent string) {
+	fmt.Printf("%sCategory:     %s\n", indent, greenStyle.Render(exp.Category))
+	if exp.Parent != "" {
+		fmt.Printf("%sParent:       %s\n", indent, exp.Parent)
+	}
+	fmt.Printf("%sMatched rule: %s\n", indent, describeMatch(exp.Matched))
+
+	if len(exp.Alternatives) > 0 {
+		fmt.Printf("%sAlso matched:\n", indent)
+		for _, m := range exp.Alternatives {
+			fmt.Printf("%s  - %s\n", indent, describeMatch(m))
+		}
+	}
+}
+
+// describeMatch renders a rule match as a human-readable sentence
+func describeMatch(m config.Match) string {
+	switch m.Rule {
+	case config.RuleIdentity:
+		return fmt.Sprintf("identity %q → %s", m.Pattern, m.Vendor)
+	case config.RuleDomain:
+		return fmt.Sprintf("domain %q of vendor %s", m.Pattern, m.Vendor)
+	case config.RuleDomainWildcard:
+		return fmt.Sprintf("wildcard domain %q of vendor %s", m.Pattern, m.Vendor)
+	case config.RuleDomainPattern:
+		return fmt.Sprintf("domain pattern %q of vendor %s", m.Pattern, m.Vendor)
+	case config.RuleCompany:
+		return fmt.Sprintf("GitHub company %q of vendor %s", m.Pattern, m.Vendor)
+	case config.RuleCategory:
+		return fmt.Sprintf("%s domain %q", m.Category, m.Pattern)
+	case config.RulePersonalDomain:
+		return fmt.Sprintf("personal email provider %q → %s", m.Pattern, m.Category)
+	case config.RuleAutoDomain:
+		return fmt.Sprintf("corporate domain %q → %s (automatic classification)", m.Pattern, m.Category)
+	default:
+		return fmt.Sprintf("no rule matched → %s", m.Category)
+	}
+}
+
+// firstLine returns the first line of a commit message
+func firstLine(message string) string {
+	if idx := strings.IndexByte(message, '\n'); idx >= 0 {
+		return message[:idx]
+	}
+	return message
+}
@@ -0,0 +1,81 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/spf13/cobra"
+
+	"github.com/sderosiaux/git-contributor-insights/pkg/tui"
+)
+
+var (
+	exploreBreakdown string
+
+	exploreCmd = &cobra.Command{
+		Use:   "explore [repo-path]",
+		Short: "Browse the analysis interactively in the terminal",
+		Long: `Fetch the history once and explore it interactively: switch between the
+vendor summary and the timeline, change the breakdown granularity, drill down
+from a vendor to its contributors and from a period to its commits, and toggle
+the metric (commits, additions, contributors) without re-reading the repository.
+
+Keys:
+  tab, s, t     switch between summary and timeline
+  m             cycle the metric
+  b             cycle the breakdown (year, quarter, month, week)
+  ↑/↓, j/k      move; pgup/pgdown, g/G to scroll
+  enter         drill down (vendor → contributors, period → commits)
+  esc           go back
+  q             quit
+
+Examples:
+  ghca explore /path/to/kafka --config vendors.yaml
+  ghca explore ./repo --since 2023-01-01 --breakdown month`,
+		Args: cobra.ExactArgs(1),
+		Run:  runExplore,
+	}
+)
+
+func init() {
+	exploreCmd.Flags().StringArrayVarP(&configPaths, "config", "c", nil, "Vendor configuration YAML file (repeatable; later files extend earlier ones)")
+	exploreCmd.Flags().StringVar(&profilesPath, "profiles", "", "Profile file (.json or .csv) mapping emails/logins to companies")
+	addPersonalDomainFlags(exploreCmd)
+	exploreCmd.Flags().StringVar(&sinceDate, "since", "", "Only include commits since this date (YYYY-MM-DD)")
+	exploreCmd.Flags().StringVar(&untilDate, "until", "", "Only include commits until this date (YYYY-MM-DD)")
+	exploreCmd.Flags().IntVarP(&workers, "workers", "w", 8, "Number of concurrent workers (default: 8)")
+	exploreCmd.Flags().StringVarP(&exploreBreakdown, "breakdown", "b", "quarter", "Initial time breakdown: year, quarter, month, week")
+
+	rootCmd.AddCommand(exploreCmd)
+}
+
+func runExplore(cmd *cobra.Command, args []string) {
+	repoPath := args[0]
+
+	if !isValidBreakdown(exploreBreakdown) {
+		fmt.Fprintf(os.Stderr, "Invalid breakdown type: %s (must be: year, quarter, month, week)\n", exploreBreakdown)
+		os.Exit(1)
+	}
+
+	printBanner()
+
+	cfg := loadConfig()
+	since, until := parseDateFilters()
+
+	fetcher, repoName := openRepository(repoPath)
+	commits := fetchCommits(fetcher, since, until)
+
+	if len(commits) == 0 {
+		fmt.Println(yellowStyle.Render("No commits found in the specified date range"))
+		return
+	}
+
+	applyProfiles(commits)
+
+	program := tea.NewProgram(tui.NewExplorer(commits, cfg, repoName, exploreBreakdown), tea.WithAltScreen())
+	if _, err := program.Run(); err != nil {
+		fmt.Fprintf(os.Stderr, "Error running explorer: %v\n", err)
+		os.Exit(1)
+	}
+}
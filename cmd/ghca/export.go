@@ -0,0 +1,87 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/sderosiaux/git-contributor-insights/pkg/analyzer"
+	"github.com/sderosiaux/git-contributor-insights/pkg/export"
+)
+
+var (
+	sqlitePath string
+
+	exportCmd = &cobra.Command{
+		Use:   "export [repo-path]",
+		Short: "Export commits and classifications to SQLite for ad-hoc queries",
+		Long: `Write the full commit dataset into a SQLite database: commits, per-file line
+stats, author identities, the classification of every commit (with the rule
+that decided it) and its year/quarter/month/week periods.
+
+Tables: commits, file_changes, identities, classifications, commit_periods
+and export_info. An existing database file is replaced.
+
+Examples:
+  ghca export /path/to/kafka --config vendors.yaml --sqlite kafka.db
+  ghca export ./repo --since 2023-01-01 --sqlite out.db
+  sqlite3 kafka.db "SELECT category, COUNT(*) FROM classifications GROUP BY category"`,
+		Args: cobra.ExactArgs(1),
+		Run:  runExport,
+	}
+)
+
+func init() {
+	exportCmd.Flags().StringVar(&sqlitePath, "sqlite", "", "SQLite database file to write (required)")
+	exportCmd.Flags().StringArrayVarP(&configPaths, "config", "c", nil, "Vendor configuration YAML file (repeatable; later files extend earlier ones)")
+	exportCmd.Flags().StringVar(&profilesPath, "profiles", "", "Profile file (.json or .csv) mapping emails/logins to companies")
+	addPersonalDomainFlags(exportCmd)
+	exportCmd.Flags().StringVar(&sinceDate, "since", "", "Only include commits since this date (YYYY-MM-DD)")
+	exportCmd.Flags().StringVar(&untilDate, "until", "", "Only include commits until this date (YYYY-MM-DD)")
+	exportCmd.Flags().IntVarP(&workers, "workers", "w", 8, "Number of concurrent workers (default: 8)")
+	exportCmd.MarkFlagRequired("sqlite")
+
+	rootCmd.AddCommand(exportCmd)
+}
+
+func runExport(cmd *cobra.Command, args []string) {
+	repoPath := args[0]
+
+	printBanner()
+
+	cfg := loadConfig()
+	since, until := parseDateFilters()
+
+	fetcher, repoName := openRepository(repoPath)
+	fetcher.FileStats = true
+	commits := fetchCommits(fetcher, since, until)
+
+	if len(commits) == 0 {
+		fmt.Println(yellowStyle.Render("No commits found in the specified date range"))
+		return
+	}
+
+	applyProfiles(commits)
+
+	err := export.WriteSQLite(sqlitePath, &export.Dataset{
+		RepoName:    repoName,
+		Commits:     commits,
+		Config:      cfg,
+		ConfigFiles: configPaths,
+		GeneratedAt: time.Now(),
+	})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error writing SQLite database: %v\n", err)
+		os.Exit(1)
+	}
+
+	fmt.Printf("%s Exported %s commits to %s\n",
+		greenStyle.Render("✓"),
+		analyzer.FormatNumber(len(commits)),
+		sqlitePath,
+	)
+
+	printFooter()
+}
@@ -2,30 +2,79 @@ package main
 
 import (
 	"fmt"
+	"io"
 	"os"
+	"strconv"
+	"strings"
+	"text/template"
 	"time"
 
 	"github.com/charmbracelet/lipgloss"
 	"github.com/spf13/cobra"
 
 	"github.com/sderosiaux/git-contributor-insights/pkg/analyzer"
+	"github.com/sderosiaux/git-contributor-insights/pkg/atomicfile"
 	"github.com/sderosiaux/git-contributor-insights/pkg/config"
 	"github.com/sderosiaux/git-contributor-insights/pkg/git"
+	"github.com/sderosiaux/git-contributor-insights/pkg/profiles"
+	"github.com/sderosiaux/git-contributor-insights/pkg/report"
 	"github.com/sderosiaux/git-contributor-insights/pkg/tui"
+	"github.com/sderosiaux/git-contributor-insights/pkg/types"
 )
 
 var (
-	configPath string
-	sinceDate  string
-	untilDate  string
-	workers    int
-	breakdown  string
+	// Styles
+	cyanStyle   = lipgloss.NewStyle().Foreground(lipgloss.Color("14"))
+	greenStyle  = lipgloss.NewStyle().Foreground(lipgloss.Color("10"))
+	yellowStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("11"))
+	redStyle    = lipgloss.NewStyle().Foreground(lipgloss.Color("9"))
+	dimStyle    = lipgloss.NewStyle().Foreground(lipgloss.Color("240"))
+)
+
+var (
+	configPaths     []string
+	profilesPath    string
+	personalDomains []string
+	replacePersonal bool
+	sinceDate       string
+	untilDate       string
+	workers         int
+	breakdown       string
+	topContributors int
+	topVendors      int
+	minShare        string
+	rollup          bool
+	outputFormat    string
+	outputFile      string
+	outputDir       string
+	mermaid         bool
+	templatePath    string
+	colorMode       string
+
+	// reportTemplate is the parsed --template file
+	reportTemplate *template.Template
+
+	// status receives progress messages; it moves to stderr when the
+	// report itself is written to stdout in a machine-readable format
+	status io.Writer = os.Stdout
 
 	rootCmd = &cobra.Command{
 		Use:   "ghca",
 		Short: "GitHub Contributor Analyzer - Analyze contributor patterns in repositories",
 		Long: `GitHub Contributor Analyzer (ghca) is a fast tool to analyze Git repository
 contributor patterns, identifying vendor vs community contributions.`,
+		PersistentPreRun: func(cmd *cobra.Command, args []string) {
+			// A report written to --output-file is never a terminal, so auto
+			// mode keeps it plain even when stdout is one
+			out := os.Stdout
+			if outputFile != "" {
+				out = nil
+			}
+			if err := tui.SetColorMode(colorMode, out); err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+		},
 	}
 
 	analyzeCmd = &cobra.Command{
@@ -36,18 +85,40 @@ contributor patterns, identifying vendor vs community contributions.`,
 Examples:
   ghca analyze /path/to/kafka --config vendors.yaml
   ghca analyze ./repo --since 2024-01-01 --until 2024-12-31
-  ghca analyze /tmp/kafka --workers 8`,
+  ghca analyze /tmp/kafka --workers 8
+  ghca analyze ./repo --config vendors.yaml --top-contributors 5
+  ghca analyze ./repo --config vendors.yaml --rollup
+  ghca analyze ./repo --config vendors.yaml --top 8 --min-share 2%
+  ghca analyze ./repo --config vendors.yaml --breakdown quarter --output html --output-file report.html
+  ghca analyze ./repo --config vendors.yaml --breakdown month --output csv --output-dir reports/
+  ghca analyze ./repo --config vendors.yaml --output markdown --mermaid > REPORT.md
+  ghca analyze ./repo --config vendors.yaml --breakdown quarter --output svg --output-dir docs/img
+  ghca analyze ./repo --config vendors.yaml --output openmetrics --output-file /var/lib/node_exporter/ghca.prom
+  ghca analyze ./repo --config vendors.yaml --breakdown year --template report.tmpl`,
 		Args: cobra.ExactArgs(1),
 		Run:  runAnalyze,
 	}
 )
 
 func init() {
-	analyzeCmd.Flags().StringVarP(&configPath, "config", "c", "", "Path to vendor configuration YAML file")
+	rootCmd.PersistentFlags().StringVar(&colorMode, "color", tui.ColorAuto, "Colorize output: auto (terminal without NO_COLOR), always, never")
+
+	analyzeCmd.Flags().StringArrayVarP(&configPaths, "config", "c", nil, "Vendor configuration YAML file (repeatable; later files extend earlier ones)")
+	addPersonalDomainFlags(analyzeCmd)
+	analyzeCmd.Flags().StringVar(&profilesPath, "profiles", "", "Profile file (.json or .csv) mapping emails/logins to companies")
 	analyzeCmd.Flags().StringVar(&sinceDate, "since", "", "Only analyze commits since this date (YYYY-MM-DD)")
 	analyzeCmd.Flags().StringVar(&untilDate, "until", "", "Only analyze commits until this date (YYYY-MM-DD)")
 	analyzeCmd.Flags().IntVarP(&workers, "workers", "w", 8, "Number of concurrent workers (default: 8)")
 	analyzeCmd.Flags().StringVarP(&breakdown, "breakdown", "b", "", "Time breakdown: year, quarter, month, week (e.g., --breakdown year)")
+	analyzeCmd.Flags().IntVar(&topContributors, "top-contributors", 0, "List the top N contributors within each vendor (0 disables)")
+	analyzeCmd.Flags().IntVar(&topVendors, "top", 0, "Show the N largest vendors and group the rest as others (default: 5 without --config in text output; 0 shows all)")
+	analyzeCmd.Flags().StringVar(&minShare, "min-share", "", "Group vendors below this share of commits as others (e.g. 2%)")
+	analyzeCmd.Flags().BoolVar(&rollup, "rollup", false, "Aggregate vendors to their parent company (e.g. linkedin -> microsoft)")
+	analyzeCmd.Flags().StringVarP(&outputFormat, "output", "o", report.FormatText, "Output format: text, html, csv, markdown, svg, openmetrics")
+	analyzeCmd.Flags().StringVar(&outputFile, "output-file", "", "Write the report to this file instead of stdout")
+	analyzeCmd.Flags().StringVar(&outputDir, "output-dir", ".", "Directory for multi-file formats (csv, svg)")
+	analyzeCmd.Flags().BoolVar(&mermaid, "mermaid", false, "Add Mermaid pie/xychart blocks to markdown output")
+	analyzeCmd.Flags().StringVar(&templatePath, "template", "", "Render the report with this Go text/template file")
 
 	rootCmd.AddCommand(analyzeCmd)
 }
@@ -62,43 +133,281 @@ func main() {
 func runAnalyze(cmd *cobra.Command, args []string) {
 	repoPath := args[0]
 
-	// Styles
-	cyan := lipgloss.NewStyle().Foreground(lipgloss.Color("14"))
-	green := lipgloss.NewStyle().Foreground(lipgloss.Color("10"))
-	yellow := lipgloss.NewStyle().Foreground(lipgloss.Color("11"))
-	dim := lipgloss.NewStyle().Foreground(lipgloss.Color("240"))
+	validateOutputFormat()
+	if breakdown != "" && !isValidBreakdown(breakdown) {
+		fmt.Fprintf(os.Stderr, "Invalid breakdown type: %s (must be: year, quarter, month, week)\n", breakdown)
+		os.Exit(1)
+	}
 
-	fmt.Println(cyan.Bold(true).Render("GitHub Contributor Analyzer v1.0.0 (Go)"))
-	fmt.Println(dim.Render("Mode: Local Git repository (high-performance)"))
-	fmt.Println()
+	share, err := parseMinShare(minShare)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	grouping := analyzer.Grouping{Top: topVendors, MinShare: share}
 
-	// Load configuration
-	var cfg *config.Config
+	printBanner()
+
+	cfg := loadConfig()
+	since, until := parseDateFilters()
+
+	// Automatic classification yields many domains; keep the text view readable
+	if !cmd.Flags().Changed("top") && len(cfg.Vendors) == 0 && outputFormat == report.FormatText {
+		grouping.Top = 5
+	}
+
+	if rollup && !cfg.HasParents() {
+		fmt.Fprintln(status, yellowStyle.Render("ℹ")+" --rollup has no effect: no vendor declares a parent")
+		fmt.Fprintln(status)
+	}
+
+	fetcher, repoName := openRepository(repoPath)
+	commits := fetchCommits(fetcher, since, until)
+
+	if len(commits) == 0 {
+		fmt.Fprintln(status, yellowStyle.Render("No commits found in the specified date range"))
+		return
+	}
+
+	applyProfiles(commits)
+
+	// Fetch contributors
+	contributors, err := fetcher.FetchContributors()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error fetching contributors: %v\n", err)
+		os.Exit(1)
+	}
+
+	fmt.Fprintf(status, "%s Found %s unique contributors\n",
+		greenStyle.Render("✓"),
+		analyzer.FormatNumber(len(contributors)),
+	)
+	fmt.Fprintln(status)
+
+	// Analyze with spinner
+	spinner := tui.NewSpinner(status, "Computing metrics...")
+	spinner.Start()
+
+	rep := &report.Report{
+		TopContributors: topContributors,
+		GeneratedAt:     time.Now(),
+		PinnedColors:    cfg.VendorColors(),
+	}
+	if breakdown != "" {
+		rep.Timeline = analyzer.AnalyzeTimeline(commits, cfg, repoName, breakdown)
+		if rollup {
+			rep.Timeline = analyzer.RollupTimeline(rep.Timeline, cfg)
+		}
+		rep.Timeline = analyzer.GroupTimeline(rep.Timeline, grouping)
+	}
+
+	// The text timeline view replaces the summary; other formats include both
+	if breakdown == "" || outputFormat != report.FormatText {
+		an := analyzer.New(cfg)
+		rep.Analysis = an.Analyze(commits, contributors, repoName)
+		if rollup {
+			rep.Analysis = analyzer.Rollup(rep.Analysis, cfg)
+		}
+		rep.Analysis = analyzer.Group(rep.Analysis, grouping)
+	}
+	spinner.Stop()
+
+	if rep.Timeline != nil {
+		fmt.Fprintln(status, greenStyle.Render("✓")+" Timeline analysis complete")
+	} else {
+		fmt.Fprintln(status, greenStyle.Render("✓")+" Analysis complete")
+	}
+	fmt.Fprintln(status)
+
+	writeReport(rep)
+
+	printFooter()
+}
+
+// validateOutputFormat checks --output and --template and moves status
+// messages to stderr for formats other than text, so the report can be piped
+func validateOutputFormat() {
+	if templatePath != "" {
+		if outputFormat != report.FormatText {
+			fmt.Fprintln(os.Stderr, "Error: --template cannot be combined with --output "+outputFormat)
+			os.Exit(1)
+		}
+
+		tmpl, err := report.ParseTemplate(templatePath)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error loading template: %v\n", err)
+			os.Exit(1)
+		}
+		reportTemplate = tmpl
+		outputFormat = report.FormatTemplate
+	}
+
+	switch outputFormat {
+	case report.FormatText:
+	case report.FormatHTML, report.FormatCSV, report.FormatMarkdown, report.FormatSVG, report.FormatOpenMetrics:
+		status = os.Stderr
+	case report.FormatTemplate:
+		if reportTemplate == nil {
+			fmt.Fprintln(os.Stderr, "Error: use --template <file> to render a template")
+			os.Exit(1)
+		}
+		status = os.Stderr
+	default:
+		fmt.Fprintf(os.Stderr, "Invalid output format: %s (must be: text, html, csv, markdown, svg, openmetrics)\n", outputFormat)
+		os.Exit(1)
+	}
+}
+
+// writeReport renders the report in the --output format to stdout,
+// --output-file or --output-dir
+func writeReport(rep *report.Report) {
+	// Multi-file formats write into --output-dir
+	if outputFormat == report.FormatCSV || outputFormat == report.FormatSVG {
+		write := report.WriteCSV
+		if outputFormat == report.FormatSVG {
+			write = report.WriteSVG
+		}
+
+		paths, err := write(outputDir, rep)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error writing %s files: %v\n", outputFormat, err)
+			os.Exit(1)
+		}
+		for _, path := range paths {
+			fmt.Fprintln(status, greenStyle.Render("✓")+" Wrote "+path)
+		}
+		return
+	}
+
+	render := func(out io.Writer) error {
+		switch outputFormat {
+		case report.FormatHTML:
+			return report.WriteHTML(out, rep)
+		case report.FormatMarkdown:
+			return report.WriteMarkdown(out, rep, mermaid)
+		case report.FormatOpenMetrics:
+			return report.WriteOpenMetrics(out, rep)
+		case report.FormatTemplate:
+			return report.WriteTemplate(out, rep, reportTemplate)
+		}
+
+		// Fit the terminal; files get the full layout
+		options := tui.Options{TopContributors: topContributors, Colors: rep.PinnedColors}
+		if outputFile == "" {
+			options.Width = tui.TerminalWidth(os.Stdout)
+		}
+
+		var err error
+		if rep.Timeline != nil {
+			_, err = fmt.Fprintln(out, tui.NewTimelineWithOptions(rep.Timeline, options).Render())
+		} else {
+			_, err = fmt.Fprintln(out, tui.NewWithOptions(rep.Analysis, options).Render())
+		}
+		return err
+	}
+
+	// Files are replaced only once complete, so scrapers never read half a report
 	var err error
+	if outputFile != "" {
+		err = atomicfile.Write(outputFile, render)
+	} else {
+		err = render(os.Stdout)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error writing report: %v\n", err)
+		os.Exit(1)
+	}
 
-	if configPath != "" {
-		cfg, err = config.Load(configPath)
+	if outputFile != "" {
+		fmt.Fprintln(status, greenStyle.Render("✓")+" Report written to "+outputFile)
+	}
+}
+
+// printBanner prints the tool banner shown before every command
+func printBanner() {
+	fmt.Fprintln(status, cyanStyle.Bold(true).Render("GitHub Contributor Analyzer v1.0.0 (Go)"))
+	fmt.Fprintln(status, dimStyle.Render("Mode: Local Git repository (high-performance)"))
+	fmt.Fprintln(status)
+}
+
+// printFooter prints the closing attribution line
+func printFooter() {
+	fmt.Fprintln(status)
+	fmt.Fprintln(status, dimStyle.Render("Powered by Git Contributor Insights - https://github.com/sderosiaux/git-contributor-insights"))
+}
+
+// addPersonalDomainFlags registers the flags customizing the personal email provider list
+func addPersonalDomainFlags(cmd *cobra.Command) {
+	cmd.Flags().StringSliceVar(&personalDomains, "personal-domains", nil, "Extra personal email providers, counted as community (comma-separated)")
+	cmd.Flags().BoolVar(&replacePersonal, "replace-personal-domains", false, "Replace the built-in personal provider list instead of extending it")
+}
+
+// loadConfig loads the vendor configuration from --config, or returns an
+// empty config that falls back to automatic domain classification
+func loadConfig() *config.Config {
+	var cfg *config.Config
+
+	if len(configPaths) > 0 {
+		var err error
+		cfg, err = config.LoadAll(configPaths...)
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "Error loading config: %v\n", err)
 			os.Exit(1)
 		}
 
 		vendors := cfg.GetVendorNames()
-		fmt.Println(green.Render("✓") + " Loaded vendor config: " + joinStrings(vendors, ", "))
+		fmt.Fprintln(status, greenStyle.Render("✓")+" Loaded vendor config: "+joinStrings(vendors, ", "))
 	} else {
 		// Create empty config (will use automatic domain classification)
 		cfg = &config.Config{
 			Vendors: make(map[string]config.VendorConfig),
 		}
-		fmt.Println(yellow.Render("ℹ") + " No vendor config - using automatic domain classification")
-		fmt.Println(dim.Render("  Personal emails (gmail, yahoo, etc.) → 'community'"))
-		fmt.Println(dim.Render("  Corporate emails → '@domain' (e.g., '@confluent.io', '@amazon.com')"))
-		fmt.Println(dim.Render("  Use --config to specify custom vendor identification rules"))
+		fmt.Fprintln(status, yellowStyle.Render("ℹ")+" No vendor config - using automatic domain classification")
+		fmt.Fprintln(status, dimStyle.Render("  Personal emails (gmail, yahoo, etc.) → 'community'"))
+		fmt.Fprintln(status, dimStyle.Render("  University and foundation emails → 'academia', 'foundation'"))
+		fmt.Fprintln(status, dimStyle.Render("  Corporate emails → '@domain' (e.g., '@confluent.io', '@amazon.com')"))
+		fmt.Fprintln(status, dimStyle.Render("  Use --config to specify custom vendor identification rules"))
+	}
+
+	if len(personalDomains) > 0 || replacePersonal {
+		cfg.AddPersonalDomains(personalDomains, replacePersonal)
+
+		mode := "extending"
+		if cfg.PersonalDomains.Replace {
+			mode = "replacing"
+		}
+		fmt.Fprintln(status, dimStyle.Render(fmt.Sprintf("  Personal email providers: %d custom entries (%s built-in list)",
+			len(cfg.PersonalDomains.Domains), mode)))
 	}
 
-	fmt.Println()
+	fmt.Fprintln(status)
+	return cfg
+}
 
-	// Parse date filters
+// applyProfiles joins commits against the --profiles file, if any, so that
+// github_companies rules can classify them
+func applyProfiles(commits []*types.CommitData) {
+	if profilesPath == "" {
+		return
+	}
+
+	store, err := profiles.Load(profilesPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error loading profiles: %v\n", err)
+		os.Exit(1)
+	}
+
+	matched := analyzer.ApplyProfiles(commits, store)
+	fmt.Fprintf(status, "%s Loaded %s profiles (%s commits with a company)\n",
+		greenStyle.Render("✓"),
+		analyzer.FormatNumber(store.Len()),
+		analyzer.FormatNumber(matched),
+	)
+	fmt.Fprintln(status)
+}
+
+// parseDateFilters parses --since and --until
+func parseDateFilters() (*time.Time, *time.Time) {
 	var since, until *time.Time
 
 	if sinceDate != "" {
@@ -119,8 +428,12 @@ func runAnalyze(cmd *cobra.Command, args []string) {
 		until = &t
 	}
 
-	// Open repository
-	fmt.Println(cyan.Render("Opening local repository: ") + repoPath)
+	return since, until
+}
+
+// openRepository opens the local repository and resolves its display name
+func openRepository(repoPath string) (*git.Fetcher, string) {
+	fmt.Fprintln(status, cyanStyle.Render("Opening local repository: ")+repoPath)
 	fetcher, err := git.NewFetcher(repoPath)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error opening repository: %v\n", err)
@@ -128,11 +441,15 @@ func runAnalyze(cmd *cobra.Command, args []string) {
 	}
 
 	repoName := fetcher.GetRepoName()
-	fmt.Println(green.Render("✓") + " Repository: " + repoName)
-	fmt.Println()
+	fmt.Fprintln(status, greenStyle.Render("✓")+" Repository: "+repoName)
+	fmt.Fprintln(status)
+
+	return fetcher, repoName
+}
 
-	// Fetch commits with spinner and progress
-	spinner := tui.NewSpinner(os.Stdout, "Analyzing Git history...")
+// fetchCommits walks the Git history with a progress spinner
+func fetchCommits(fetcher *git.Fetcher, since, until *time.Time) []*types.CommitData {
+	spinner := tui.NewSpinner(status, "Analyzing Git history...")
 	spinner.Start()
 	startTime := time.Now()
 
@@ -151,70 +468,33 @@ func runAnalyze(cmd *cobra.Command, args []string) {
 	}
 
 	elapsed := time.Since(startTime)
-	fmt.Printf("%s Processed %s commits in %s (%.0f commits/sec)\n",
-		green.Render("✓"),
+	fmt.Fprintf(status, "%s Processed %s commits in %s (%.0f commits/sec)\n",
+		greenStyle.Render("✓"),
 		analyzer.FormatNumber(len(commits)),
 		elapsed.Round(time.Millisecond),
 		float64(len(commits))/elapsed.Seconds(),
 	)
-	fmt.Println()
-
-	if len(commits) == 0 {
-		fmt.Println(yellow.Render("No commits found in the specified date range"))
-		return
-	}
-
-	// Fetch contributors
-	contributors, err := fetcher.FetchContributors()
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error fetching contributors: %v\n", err)
-		os.Exit(1)
-	}
-
-	fmt.Printf("%s Found %s unique contributors\n",
-		green.Render("✓"),
-		analyzer.FormatNumber(len(contributors)),
-	)
-	fmt.Println()
-
-	// Analyze with spinner
-	spinner = tui.NewSpinner(os.Stdout, "Computing metrics...")
-	spinner.Start()
-
-	if breakdown != "" {
-		// Validate breakdown type
-		validBreakdowns := map[string]bool{"year": true, "quarter": true, "month": true, "week": true}
-		if !validBreakdowns[breakdown] {
-			spinner.Stop()
-			fmt.Fprintf(os.Stderr, "Invalid breakdown type: %s (must be: year, quarter, month, week)\n", breakdown)
-			os.Exit(1)
-		}
-
-		// Timeline analysis
-		timeline := analyzer.AnalyzeTimeline(commits, cfg, repoName, breakdown)
-		spinner.Stop()
-		fmt.Println(green.Render("✓") + " Timeline analysis complete")
-		fmt.Println()
+	fmt.Fprintln(status)
 
-		// Display timeline
-		display := tui.NewTimeline(timeline)
-		fmt.Println(display.Render())
-	} else {
-		// Standard analysis
-		an := analyzer.New(cfg)
-		analysis := an.Analyze(commits, contributors, repoName)
-		spinner.Stop()
+	return commits
+}
 
-		fmt.Println(green.Render("✓") + " Analysis complete")
-		fmt.Println()
+// isValidBreakdown reports whether b is a supported time breakdown
+func isValidBreakdown(b string) bool {
+	validBreakdowns := map[string]bool{"year": true, "quarter": true, "month": true, "week": true}
+	return validBreakdowns[b]
+}
 
-		// Display results
-		display := tui.New(analysis)
-		fmt.Println(display.Render())
+// parseMinShare parses --min-share as a percentage ("2%" or "2")
+func parseMinShare(value string) (float64, error) {
+	if value == "" {
+		return 0, nil
 	}
-
-	fmt.Println()
-	fmt.Println(dim.Render("Powered by Git Contributor Insights - https://github.com/sderosiaux/git-contributor-insights"))
+	share, err := strconv.ParseFloat(strings.TrimSpace(strings.TrimSuffix(value, "%")), 64)
+	if err != nil || share < 0 || share > 100 {
+		return 0, fmt.Errorf("invalid --min-share %q (must be a percentage between 0 and 100, e.g. 2%%)", value)
+	}
+	return share, nil
 }
 
 func joinStrings(strs []string, sep string) string {
@@ -0,0 +1,161 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/sderosiaux/git-contributor-insights/pkg/analyzer"
+	"github.com/sderosiaux/git-contributor-insights/pkg/profiles"
+	"github.com/sderosiaux/git-contributor-insights/pkg/tui"
+)
+
+var (
+	profilesOutput  string
+	profilesAPIURL  string
+	profilesToken   string
+	profilesRepo    string
+	profilesRefresh bool
+
+	profilesCmd = &cobra.Command{
+		Use:   "profiles",
+		Short: "Manage the offline contributor profile snapshot",
+	}
+
+	profilesFetchCmd = &cobra.Command{
+		Use:   "fetch [repo-path]",
+		Short: "Populate a profile file from a GitHub-compatible API",
+		Long: `Resolve every commit author to a GitHub account and record the account's
+company in a profile file (.json or .csv). Pass the file to analyze with
+--profiles so that github_companies rules can classify contributors.
+
+Authors already in the output file are skipped unless --refresh is given, so
+an interrupted or rate-limited run can simply be repeated.
+
+Examples:
+  ghca profiles fetch /path/to/kafka -o kafka_profiles.json
+  ghca profiles fetch ./repo --repo apache/kafka --token $GITHUB_TOKEN -o profiles.csv
+  ghca profiles fetch ./repo --api-url http://localhost:8080 -o profiles.json`,
+		Args: cobra.ExactArgs(1),
+		Run:  runProfilesFetch,
+	}
+)
+
+func init() {
+	profilesFetchCmd.Flags().StringVarP(&profilesOutput, "output", "o", "profiles.json", "Profile file to write (.json or .csv)")
+	profilesFetchCmd.Flags().StringVar(&profilesAPIURL, "api-url", profiles.DefaultAPIURL, "GitHub-compatible REST API base URL")
+	profilesFetchCmd.Flags().StringVar(&profilesToken, "token", "", "API token (default: $GITHUB_TOKEN)")
+	profilesFetchCmd.Flags().StringVar(&profilesRepo, "repo", "", "Repository as owner/name (default: from the origin remote)")
+	profilesFetchCmd.Flags().BoolVar(&profilesRefresh, "refresh", false, "Look up authors already present in the output file again")
+	profilesFetchCmd.Flags().StringVar(&sinceDate, "since", "", "Only include authors of commits since this date (YYYY-MM-DD)")
+	profilesFetchCmd.Flags().StringVar(&untilDate, "until", "", "Only include authors of commits until this date (YYYY-MM-DD)")
+	profilesFetchCmd.Flags().IntVarP(&workers, "workers", "w", 8, "Number of concurrent workers (default: 8)")
+
+	profilesCmd.AddCommand(profilesFetchCmd)
+	rootCmd.AddCommand(profilesCmd)
+}
+
+func runProfilesFetch(cmd *cobra.Command, args []string) {
+	repoPath := args[0]
+
+	printBanner()
+
+	since, until := parseDateFilters()
+	fetcher, repoName := openRepository(repoPath)
+	commits := fetchCommits(fetcher, since, until)
+
+	if profilesRepo == "" {
+		profilesRepo = repoName
+	}
+	repo, err := profiles.ParseRepo(profilesRepo)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v; pass --repo owner/name\n", err)
+		os.Exit(1)
+	}
+	profilesRepo = repo
+	// Read here rather than as the flag default, which --help would print
+	if profilesToken == "" {
+		profilesToken = os.Getenv("GITHUB_TOKEN")
+	}
+
+	// Start from the existing snapshot so reruns only fetch new authors
+	store := profiles.NewStore(nil)
+	if _, err := os.Stat(profilesOutput); err == nil {
+		store, err = profiles.Load(profilesOutput)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error loading existing profiles: %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Printf("%s Loaded %s existing profiles from %s\n",
+			greenStyle.Render("✓"), analyzer.FormatNumber(store.Len()), profilesOutput)
+	}
+
+	// One entry per email, keeping the most recent commit (log order is newest first)
+	seen := make(map[string]bool)
+	authors := make([]profiles.Author, 0)
+	for _, commit := range commits {
+		if commit.AuthorEmail == "" || seen[commit.AuthorEmail] {
+			continue
+		}
+		seen[commit.AuthorEmail] = true
+		if !profilesRefresh && store.Lookup(commit.AuthorEmail) != nil {
+			continue
+		}
+		authors = append(authors, profiles.Author{
+			Email: commit.AuthorEmail,
+			Name:  commit.AuthorName,
+			SHA:   commit.SHA,
+		})
+	}
+
+	fmt.Printf("%s %s authors to look up on %s (%s)\n",
+		greenStyle.Render("✓"), analyzer.FormatNumber(len(authors)), profilesAPIURL, profilesRepo)
+	fmt.Println()
+
+	client := profiles.NewClient(profilesAPIURL, profilesToken)
+
+	spinner := tui.NewSpinner(os.Stdout, "Fetching profiles...")
+	spinner.Start()
+	fetched, err := client.FetchProfiles(profilesRepo, authors, func(processed, total int) {
+		spinner.UpdateProgress("Fetching profiles...", processed, total)
+	})
+	spinner.Stop()
+
+	// An aborted run resolves nothing, so keep the existing file untouched
+	if err != nil && !errors.Is(err, profiles.ErrRateLimited) && len(fetched) == 0 {
+		fmt.Fprintf(os.Stderr, "Error fetching profiles: %v\n", err)
+		os.Exit(1)
+	}
+
+	withCompany := 0
+	for _, p := range fetched {
+		store.Add(p)
+		if p.Company != "" {
+			withCompany++
+		}
+	}
+
+	if saveErr := profiles.Save(profilesOutput, store.Profiles()); saveErr != nil {
+		fmt.Fprintf(os.Stderr, "Error writing profiles: %v\n", saveErr)
+		os.Exit(1)
+	}
+
+	fmt.Printf("%s Resolved %s of %s authors (%s with a company), wrote %s profiles to %s\n",
+		greenStyle.Render("✓"),
+		analyzer.FormatNumber(len(fetched)),
+		analyzer.FormatNumber(len(authors)),
+		analyzer.FormatNumber(withCompany),
+		analyzer.FormatNumber(store.Len()),
+		profilesOutput,
+	)
+
+	switch {
+	case errors.Is(err, profiles.ErrRateLimited):
+		fmt.Println(yellowStyle.Render("ℹ") + " API rate limit reached - rerun later to fetch the remaining authors")
+	case err != nil:
+		fmt.Fprintf(os.Stderr, "Error fetching profiles: %v\n", err)
+		os.Exit(1)
+	}
+}
@@ -41,7 +41,7 @@ func (a *Analyzer) Analyze(commits []*types.CommitData, contributors []*types.Co
 	// Process each commit
 	for _, commit := range commits {
 		// Classify contributor
-		vendor := a.config.Classify(commit.AuthorEmail, "")
+		vendor := classifyCommit(a.config, commit)
 
 		// Get or create metrics for this vendor
 		metrics := vendorMetrics[vendor]
@@ -56,12 +56,8 @@ func (a *Analyzer) Analyze(commits []*types.CommitData, contributors []*types.Co
 		metrics.TotalDeletions += commit.Deletions
 
 		// Track contributor
-		contributorID := commit.AuthorEmail
-		if contributorID == "" {
-			contributorID = commit.AuthorName
-		}
-		if contributorID != "" {
-			metrics.UniqueContributors[contributorID] = true
+		if contributorID := commit.ContributorID(); contributorID != "" {
+			metrics.TrackContributor(commit)
 			allContributors[contributorID] = true
 		}
 
@@ -89,37 +85,28 @@ func (a *Analyzer) Analyze(commits []*types.CommitData, contributors []*types.Co
 	}
 }
 
-// GetSortedVendors returns vendors sorted by a metric
-func GetSortedVendors(analysis *types.RepositoryAnalysis, by string, reverse bool) []string {
-	vendors := make([]string, 0, len(analysis.VendorMetrics))
-	for name := range analysis.VendorMetrics {
-		vendors = append(vendors, name)
-	}
-
-	sort.Slice(vendors, func(i, j int) bool {
-		vi := analysis.VendorMetrics[vendors[i]]
-		vj := analysis.VendorMetrics[vendors[j]]
-
-		var result bool
-		switch by {
-		case "commits":
-			result = vi.TotalCommits > vj.TotalCommits
-		case "additions":
-			result = vi.TotalAdditions > vj.TotalAdditions
-		case "contributors":
-			result = vi.ContributorCount() > vj.ContributorCount()
-		default:
-			result = vi.TotalCommits > vj.TotalCommits
-		}
-
-		if !reverse {
-			result = !result
-		}
-
-		return result
+// classifyCommit classifies a commit's author using every signal the commit carries
+func classifyCommit(cfg *config.Config, commit *types.CommitData) string {
+	return cfg.ClassifyContributor(config.Contributor{
+		Name:    commit.AuthorName,
+		Email:   commit.AuthorEmail,
+		Company: commit.AuthorCompany,
+		Date:    commit.Date,
 	})
+}
 
-	return vendors
+// GetSortedVendors returns vendors sorted by a metric, largest first when
+// reverse is set; ties are always broken by name so the order is stable.
+// The "others" bucket always comes last.
+func GetSortedVendors(analysis *types.RepositoryAnalysis, by string, reverse bool) []string {
+	vendors := analysis.GetSortedVendors(by)
+	if !reverse && by != "name" {
+		// Stable, so vendors with equal values stay in name order
+		sort.SliceStable(vendors, func(i, j int) bool {
+			return analysis.VendorMetrics[vendors[i]].Metric(by) < analysis.VendorMetrics[vendors[j]].Metric(by)
+		})
+	}
+	return OthersLast(vendors)
 }
 
 // GetTimelineData generates timeline data for all vendors
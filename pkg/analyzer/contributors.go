@@ -0,0 +1,121 @@
+package analyzer
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/sderosiaux/git-contributor-insights/pkg/config"
+	"github.com/sderosiaux/git-contributor-insights/pkg/types"
+)
+
+// ContributorProfile describes a single identity's activity and classification
+type ContributorProfile struct {
+	*types.ContributorData
+	Category      string
+	ActivePeriods []string // sorted period keys with at least one commit
+}
+
+// ContributorFilter restricts which contributor profiles are reported
+type ContributorFilter struct {
+	Vendors    []string // empty means all categories
+	MinCommits int
+	MinLines   int
+}
+
+// AnalyzeContributors aggregates commits into one profile per contributor.
+// Active periods are bucketed with the given breakdown type (year, quarter,
+// month, week) and the category is the classification of the latest commit.
+func AnalyzeContributors(commits []*types.CommitData, cfg *config.Config, breakdownType string) []*ContributorProfile {
+	profiles := make(map[string]*ContributorProfile)
+	periods := make(map[string]map[string]bool)
+	latest := make(map[string]time.Time)
+
+	for _, commit := range commits {
+		id := commit.ContributorID()
+		if id == "" {
+			continue
+		}
+
+		profile, ok := profiles[id]
+		if !ok {
+			profile = &ContributorProfile{ContributorData: &types.ContributorData{}}
+			profiles[id] = profile
+			periods[id] = make(map[string]bool)
+		}
+		profile.AddCommit(commit)
+		periods[id][PeriodKey(commit.Date, breakdownType)] = true
+
+		if !commit.Date.Before(latest[id]) {
+			latest[id] = commit.Date
+			profile.Category = classifyCommit(cfg, commit)
+		}
+	}
+
+	result := make([]*ContributorProfile, 0, len(profiles))
+	for id, profile := range profiles {
+		for period := range periods[id] {
+			profile.ActivePeriods = append(profile.ActivePeriods, period)
+		}
+		sort.Strings(profile.ActivePeriods)
+		result = append(result, profile)
+	}
+
+	SortContributorProfiles(result, "commits", true)
+	return result
+}
+
+// FilterContributors returns the profiles matching the filter
+func FilterContributors(profiles []*ContributorProfile, filter ContributorFilter) []*ContributorProfile {
+	vendors := make(map[string]bool, len(filter.Vendors))
+	for _, v := range filter.Vendors {
+		vendors[strings.ToLower(v)] = true
+	}
+
+	result := make([]*ContributorProfile, 0, len(profiles))
+	for _, p := range profiles {
+		if len(vendors) > 0 && !vendors[strings.ToLower(p.Category)] {
+			continue
+		}
+		if p.Commits < filter.MinCommits || p.LinesChanged() < filter.MinLines {
+			continue
+		}
+		result = append(result, p)
+	}
+	return result
+}
+
+// SortContributorProfiles sorts profiles in place by commits, lines, additions,
+// periods, first, last or name. Ties are broken by email for stable output.
+func SortContributorProfiles(profiles []*ContributorProfile, by string, reverse bool) {
+	sort.SliceStable(profiles, func(i, j int) bool {
+		pi, pj := profiles[i], profiles[j]
+
+		var less, equal bool
+		switch by {
+		case "lines":
+			less, equal = pi.LinesChanged() < pj.LinesChanged(), pi.LinesChanged() == pj.LinesChanged()
+		case "additions":
+			less, equal = pi.Additions < pj.Additions, pi.Additions == pj.Additions
+		case "periods":
+			less, equal = len(pi.ActivePeriods) < len(pj.ActivePeriods), len(pi.ActivePeriods) == len(pj.ActivePeriods)
+		case "first":
+			less, equal = pi.FirstCommit.Before(pj.FirstCommit), pi.FirstCommit.Equal(pj.FirstCommit)
+		case "last":
+			less, equal = pi.LastCommit.Before(pj.LastCommit), pi.LastCommit.Equal(pj.LastCommit)
+		case "name":
+			ni, nj := strings.ToLower(pi.Name), strings.ToLower(pj.Name)
+			less, equal = ni < nj, ni == nj
+		default:
+			less, equal = pi.Commits < pj.Commits, pi.Commits == pj.Commits
+		}
+
+		if equal {
+			return pi.Email < pj.Email
+		}
+		if reverse {
+			return !less
+		}
+		return less
+	})
+}
@@ -0,0 +1,110 @@
+package analyzer
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/sderosiaux/git-contributor-insights/pkg/config"
+	"github.com/sderosiaux/git-contributor-insights/pkg/types"
+)
+
+// Domain mapping status values
+const (
+	DomainMapped   = "mapped"   // a configured vendor claims the domain
+	DomainPersonal = "personal" // personal email provider, counted as community
+	DomainCategory = "category" // academia/foundation (or configured) category
+	DomainUnmapped = "unmapped" // no rule matches, counted as community or @domain
+)
+
+// NoDomain stands for commits whose author email has no domain
+const NoDomain = "(none)"
+
+// DomainStats aggregates activity for a single email domain
+type DomainStats struct {
+	Domain       string
+	Commits      int
+	Additions    int
+	Deletions    int
+	Contributors map[string]bool
+	Vendor       string // configured vendor the domain maps to, if any
+	Status       string
+}
+
+// ContributorCount returns the number of unique contributors using the domain
+func (ds *DomainStats) ContributorCount() int {
+	return len(ds.Contributors)
+}
+
+// AnalyzeDomains groups commits by author email domain and reports how the
+// config maps each one. Results are sorted by commits, then domain name.
+func AnalyzeDomains(commits []*types.CommitData, cfg *config.Config) []*DomainStats {
+	domains := make(map[string]*DomainStats)
+
+	for _, commit := range commits {
+		domain := config.EmailDomain(commit.AuthorEmail)
+		if domain == "" {
+			domain = NoDomain
+		}
+
+		stats, ok := domains[domain]
+		if !ok {
+			stats = &DomainStats{
+				Domain:       domain,
+				Contributors: make(map[string]bool),
+			}
+			stats.Vendor, stats.Status = classifyDomain(cfg, domain)
+			domains[domain] = stats
+		}
+
+		stats.Commits++
+		stats.Additions += commit.Additions
+		stats.Deletions += commit.Deletions
+		if id := commit.ContributorID(); id != "" {
+			stats.Contributors[id] = true
+		}
+	}
+
+	result := make([]*DomainStats, 0, len(domains))
+	for _, stats := range domains {
+		result = append(result, stats)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Commits != result[j].Commits {
+			return result[i].Commits > result[j].Commits
+		}
+		return result[i].Domain < result[j].Domain
+	})
+
+	return result
+}
+
+// classifyDomain returns the vendor a domain maps to and its mapping status
+func classifyDomain(cfg *config.Config, domain string) (string, string) {
+	if cfg.IsPersonalDomain(domain) {
+		return "community", DomainPersonal
+	}
+	if vendor := cfg.ClassifyByEmail("user@" + domain); vendor != "" {
+		return vendor, DomainMapped
+	}
+	if category := cfg.ClassifyByCategory("user@" + domain); category != "" {
+		return category, DomainCategory
+	}
+	return "", DomainUnmapped
+}
+
+// SuggestVendorName derives a vendor key from a domain by taking its
+// registrable label (e.g. "eu.corp.ibm.com" -> "ibm", "bbc.co.uk" -> "bbc")
+func SuggestVendorName(domain string) string {
+	labels := strings.Split(domain, ".")
+	if len(labels) < 2 {
+		return domain
+	}
+
+	idx := len(labels) - 2
+	secondLevel := map[string]bool{"co": true, "com": true, "ac": true, "org": true, "net": true, "gov": true, "edu": true}
+	if idx > 0 && secondLevel[labels[idx]] && len(labels[len(labels)-1]) == 2 {
+		idx--
+	}
+	return labels[idx]
+}
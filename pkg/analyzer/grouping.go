@@ -1,124 +1,155 @@
 package analyzer
 
 import (
-	"sort"
-
+	"github.com/sderosiaux/git-contributor-insights/pkg/config"
 	"github.com/sderosiaux/git-contributor-insights/pkg/types"
 )
 
-// VendorGroup represents grouped vendor data
-type VendorGroup struct {
-	Name               string
-	TotalCommits       int
-	TotalAdditions     int
-	TotalDeletions     int
-	UniqueContributors map[string]bool
-	IsGrouped          bool // true if this is the "others" group
+// OthersName is the bucket holding the vendors folded by a Grouping
+const OthersName = config.OthersVendor
+
+// Grouping selects the vendors shown individually; the long tail is folded
+// into a single "others" vendor. Community is always shown on its own.
+type Grouping struct {
+	Top      int     // vendors kept besides community (0 keeps all)
+	MinShare float64 // percentage of commits below which a vendor is folded (0 keeps all)
 }
 
-// GroupVendors groups vendors into top N + "others"
-// Always shows "community" separately, then top N-1 vendors, then groups the rest
-func GroupVendors(vendorMetrics map[string]*types.VendorMetrics, topN int) []*VendorGroup {
-	// Separate community from vendors
-	var communityMetrics *types.VendorMetrics
-	vendors := make(map[string]*types.VendorMetrics)
+// IsZero reports whether the grouping keeps every vendor
+func (g Grouping) IsZero() bool {
+	return g.Top <= 0 && g.MinShare <= 0
+}
 
-	for name, metrics := range vendorMetrics {
-		if name == "community" {
-			communityMetrics = metrics
-		} else {
-			vendors[name] = metrics
-		}
+// Group folds the long tail of an analysis into "others". Contributors
+// active in several folded vendors are counted once.
+func Group(analysis *types.RepositoryAnalysis, grouping Grouping) *types.RepositoryAnalysis {
+	totals := make(map[string]int, len(analysis.VendorMetrics))
+	for name, metrics := range analysis.VendorMetrics {
+		totals[name] = metrics.TotalCommits
 	}
 
-	// Sort vendors by commit count
-	vendorNames := make([]string, 0, len(vendors))
-	for name := range vendors {
-		vendorNames = append(vendorNames, name)
-	}
-	sort.Slice(vendorNames, func(i, j int) bool {
-		return vendors[vendorNames[i]].TotalCommits > vendors[vendorNames[j]].TotalCommits
-	})
-
-	// Build result
-	result := make([]*VendorGroup, 0)
-
-	// Add community first
-	if communityMetrics != nil {
-		result = append(result, &VendorGroup{
-			Name:               "community",
-			TotalCommits:       communityMetrics.TotalCommits,
-			TotalAdditions:     communityMetrics.TotalAdditions,
-			TotalDeletions:     communityMetrics.TotalDeletions,
-			UniqueContributors: communityMetrics.UniqueContributors,
-			IsGrouped:          false,
-		})
-	}
+	grouped := *analysis
+	grouped.VendorMetrics = foldVendors(analysis.VendorMetrics, grouping.folded(totals))
+	return &grouped
+}
 
-	// If we have fewer vendors than topN, show all individually
-	if len(vendorNames) <= topN {
-		for _, name := range vendorNames {
-			metrics := vendors[name]
-			result = append(result, &VendorGroup{
-				Name:               name,
-				TotalCommits:       metrics.TotalCommits,
-				TotalAdditions:     metrics.TotalAdditions,
-				TotalDeletions:     metrics.TotalDeletions,
-				UniqueContributors: metrics.UniqueContributors,
-				IsGrouped:          false,
-			})
+// GroupTimeline folds the same vendors into "others" in every period. They
+// are chosen from the totals across periods, so the timeline matches the
+// grouped summary of the same commits.
+func GroupTimeline(timeline *TimelineAnalysis, grouping Grouping) *TimelineAnalysis {
+	totals := make(map[string]int)
+	for _, period := range timeline.Periods {
+		for name, metrics := range period.VendorMetrics {
+			totals[name] += metrics.TotalCommits
 		}
-		return result
 	}
+	folded := grouping.folded(totals)
+
+	grouped := *timeline
+	grouped.Periods = make([]*TimeBreakdown, 0, len(timeline.Periods))
+	for _, period := range timeline.Periods {
+		p := *period
+		p.VendorMetrics = foldVendors(period.VendorMetrics, folded)
+		grouped.Periods = append(grouped.Periods, &p)
+	}
+	return &grouped
+}
 
-	// Show top N-1 vendors individually
-	showIndividually := topN - 1
-	for i := 0; i < showIndividually && i < len(vendorNames); i++ {
-		name := vendorNames[i]
-		metrics := vendors[name]
-		result = append(result, &VendorGroup{
-			Name:               name,
-			TotalCommits:       metrics.TotalCommits,
-			TotalAdditions:     metrics.TotalAdditions,
-			TotalDeletions:     metrics.TotalDeletions,
-			UniqueContributors: metrics.UniqueContributors,
-			IsGrouped:          false,
-		})
+// folded returns the vendors to fold given their commits: those ranked
+// below the top N and those under the minimum share. Even a single leftover
+// vendor is folded, so --top N always shows exactly N vendors.
+func (g Grouping) folded(totals map[string]int) map[string]bool {
+	if g.IsZero() {
+		return nil
 	}
 
-	// Group the rest as "others"
-	if len(vendorNames) > showIndividually {
-		othersGroup := &VendorGroup{
-			Name:               "others",
-			TotalCommits:       0,
-			TotalAdditions:     0,
-			TotalDeletions:     0,
-			UniqueContributors: make(map[string]bool),
-			IsGrouped:          true,
+	total := 0
+	candidates := make(map[string]int, len(totals))
+	for name, commits := range totals {
+		total += commits
+		if name != "community" && name != OthersName && commits > 0 {
+			candidates[name] = commits
 		}
-
-		for i := showIndividually; i < len(vendorNames); i++ {
-			metrics := vendors[vendorNames[i]]
-			othersGroup.TotalCommits += metrics.TotalCommits
-			othersGroup.TotalAdditions += metrics.TotalAdditions
-			othersGroup.TotalDeletions += metrics.TotalDeletions
-			for contributor := range metrics.UniqueContributors {
-				othersGroup.UniqueContributors[contributor] = true
-			}
+	}
+	names := types.RankNames(candidates)
+
+	folded := make(map[string]bool)
+	kept := 0
+	for _, name := range names {
+		share := float64(totals[name]) / float64(total) * 100
+		if (g.Top > 0 && kept >= g.Top) || share < g.MinShare {
+			folded[name] = true
+			continue
 		}
+		kept++
+	}
+
+	if len(folded) == 0 {
+		return nil
+	}
+	return folded
+}
 
-		result = append(result, othersGroup)
+// foldVendors merges the folded vendors into one "others" entry. A vendor
+// already named "others" joins the bucket rather than being overwritten.
+func foldVendors(vendorMetrics map[string]*types.VendorMetrics, folded map[string]bool) map[string]*types.VendorMetrics {
+	if len(folded) == 0 {
+		return vendorMetrics
 	}
 
+	result := make(map[string]*types.VendorMetrics, len(vendorMetrics))
+	var others *types.VendorMetrics
+	for name, metrics := range vendorMetrics {
+		if !folded[name] && name != OthersName {
+			result[name] = metrics
+			continue
+		}
+		if others == nil {
+			others = types.NewVendorMetrics(OthersName)
+		}
+		others.Absorb(metrics)
+	}
+	if others != nil {
+		result[OthersName] = others
+	}
 	return result
 }
 
-// ContributorCount returns the number of unique contributors
-func (vg *VendorGroup) ContributorCount() int {
-	return len(vg.UniqueContributors)
+// RankVendors returns the vendors with commits sorted by a metric (commits,
+// additions, deletions or contributors) then name, or by name alone, with
+// the "others" bucket last
+func RankVendors(vendorMetrics map[string]*types.VendorMetrics, by string) []*types.VendorMetrics {
+	values := make(map[string]int, len(vendorMetrics))
+	for name, metrics := range vendorMetrics {
+		if metrics.TotalCommits == 0 {
+			continue
+		}
+		values[name] = 0
+		if by != "name" {
+			values[name] = metrics.Metric(by)
+		}
+	}
+
+	vendors := make([]*types.VendorMetrics, 0, len(values))
+	for _, name := range OthersLast(types.RankNames(values)) {
+		vendors = append(vendors, vendorMetrics[name])
+	}
+	return vendors
 }
 
-// NetChanges returns net line changes (additions - deletions)
-func (vg *VendorGroup) NetChanges() int {
-	return vg.TotalAdditions - vg.TotalDeletions
+// OthersLast moves the "others" bucket to the end of a ranked vendor list
+func OthersLast(vendors []string) []string {
+	result := make([]string, 0, len(vendors))
+	hasOthers := false
+	for _, vendor := range vendors {
+		if vendor == OthersName {
+			hasOthers = true
+			continue
+		}
+		result = append(result, vendor)
+	}
+	if hasOthers {
+		result = append(result, OthersName)
+	}
+	return result
 }
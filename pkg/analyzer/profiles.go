@@ -0,0 +1,20 @@
+package analyzer
+
+import (
+	"github.com/sderosiaux/git-contributor-insights/pkg/profiles"
+	"github.com/sderosiaux/git-contributor-insights/pkg/types"
+)
+
+// ApplyProfiles joins commits against profile data, setting each commit's
+// AuthorCompany so that github_companies rules can classify it. Returns the
+// number of commits that received a company.
+func ApplyProfiles(commits []*types.CommitData, store *profiles.Store) int {
+	matched := 0
+	for _, commit := range commits {
+		if company := store.Company(commit.AuthorEmail); company != "" {
+			commit.AuthorCompany = company
+			matched++
+		}
+	}
+	return matched
+}
@@ -0,0 +1,54 @@
+package analyzer
+
+import (
+	"github.com/sderosiaux/git-contributor-insights/pkg/config"
+	"github.com/sderosiaux/git-contributor-insights/pkg/types"
+)
+
+// Rollup aggregates vendor metrics to their top-level parent companies
+// (e.g. linkedin -> microsoft). Each rolled-up vendor keeps its brands in
+// Children so displays can show both levels.
+func Rollup(analysis *types.RepositoryAnalysis, cfg *config.Config) *types.RepositoryAnalysis {
+	rolled := *analysis
+	rolled.VendorMetrics = RollupVendors(analysis.VendorMetrics, cfg)
+	return &rolled
+}
+
+// RollupTimeline aggregates every period of a timeline to parent companies
+func RollupTimeline(timeline *TimelineAnalysis, cfg *config.Config) *TimelineAnalysis {
+	rolled := *timeline
+	rolled.Periods = make([]*TimeBreakdown, 0, len(timeline.Periods))
+	for _, period := range timeline.Periods {
+		p := *period
+		p.VendorMetrics = RollupVendors(period.VendorMetrics, cfg)
+		rolled.Periods = append(rolled.Periods, &p)
+	}
+	return &rolled
+}
+
+// RollupVendors groups vendor metrics by parent company. Vendors without a
+// parent and without subsidiaries pass through unchanged.
+func RollupVendors(vendorMetrics map[string]*types.VendorMetrics, cfg *config.Config) map[string]*types.VendorMetrics {
+	members := make(map[string][]string)
+	for name := range vendorMetrics {
+		parent := cfg.ParentOf(name)
+		members[parent] = append(members[parent], name)
+	}
+
+	result := make(map[string]*types.VendorMetrics, len(members))
+	for parent, names := range members {
+		if len(names) == 1 && names[0] == parent {
+			result[parent] = vendorMetrics[parent]
+			continue
+		}
+
+		metrics := types.NewVendorMetrics(parent)
+		metrics.Children = make(map[string]*types.VendorMetrics, len(names))
+		for _, name := range names {
+			metrics.Absorb(vendorMetrics[name])
+			metrics.Children[name] = vendorMetrics[name]
+		}
+		result[parent] = metrics
+	}
+	return result
+}
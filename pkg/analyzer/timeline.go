@@ -40,7 +40,7 @@ func AnalyzeTimeline(commits []*types.CommitData, cfg *config.Config, repoName s
 	periodMap := make(map[string][]*types.CommitData)
 
 	for _, commit := range commits {
-		period := getPeriodKey(commit.Date, breakdownType)
+		period := PeriodKey(commit.Date, breakdownType)
 		periodMap[period] = append(periodMap[period], commit)
 	}
 
@@ -66,7 +66,7 @@ func AnalyzeTimeline(commits []*types.CommitData, cfg *config.Config, repoName s
 		// Process commits for this period
 		totalCommits := 0
 		for _, commit := range periodCommits {
-			vendor := cfg.Classify(commit.AuthorEmail, "")
+			vendor := classifyCommit(cfg, commit)
 
 			// Get or create metrics for this vendor
 			metrics := vendorMetrics[vendor]
@@ -78,7 +78,7 @@ func AnalyzeTimeline(commits []*types.CommitData, cfg *config.Config, repoName s
 			metrics.TotalCommits++
 			metrics.TotalAdditions += commit.Additions
 			metrics.TotalDeletions += commit.Deletions
-			metrics.UniqueContributors[commit.AuthorEmail] = true
+			metrics.TrackContributor(commit)
 
 			totalCommits++
 		}
@@ -117,8 +117,9 @@ func AnalyzeTimeline(commits []*types.CommitData, cfg *config.Config, repoName s
 	}
 }
 
-// getPeriodKey returns the period key for a given date and breakdown type
-func getPeriodKey(date time.Time, breakdownType string) string {
+// PeriodKey returns the period key for a given date and breakdown type
+// (e.g. "2024", "2024-Q3", "2024-07", "2024-W28")
+func PeriodKey(date time.Time, breakdownType string) string {
 	switch breakdownType {
 	case "year":
 		return fmt.Sprintf("%d", date.Year())
@@ -0,0 +1,409 @@
+package chart
+
+import (
+	"fmt"
+	"html"
+	"math"
+	"sort"
+	"strings"
+)
+
+// Slice is one labelled value of a pie or bar chart
+type Slice struct {
+	Label string
+	Value float64
+	Color string
+}
+
+// Series is one stacked layer of an area chart
+type Series struct {
+	Label  string
+	Color  string
+	Values []float64 // one value per x label
+}
+
+// Pie renders a pie chart with a legend as a standalone SVG element
+func Pie(title string, slices []Slice, size int) string {
+	return ring(title, slices, size, 0, "")
+}
+
+// Donut renders a donut chart with a legend and a caption in the hole
+// (e.g. the total number of commits)
+func Donut(title string, slices []Slice, size int, caption string) string {
+	return ring(title, slices, size, 0.58, caption)
+}
+
+// ring renders a pie (hole 0) or donut chart; hole is the inner radius as
+// a fraction of the outer radius
+func ring(title string, slices []Slice, size int, hole float64, caption string) string {
+	total := 0.0
+	for _, s := range slices {
+		total += s.Value
+	}
+
+	legendWidth := 220
+	legendHeight := 24 + len(slices)*18
+	height := size + 30
+	if legendHeight > height {
+		height = legendHeight
+	}
+
+	var out strings.Builder
+	openSVG(&out, size+legendWidth, height, title)
+	writeTitle(&out, title)
+
+	r := float64(size)/2 - 4
+	inner := r * hole
+	cx, cy := float64(size)/2, float64(size)/2+26
+
+	angle := -math.Pi / 2
+	for _, s := range slices {
+		if total == 0 || s.Value == 0 {
+			continue
+		}
+		share := s.Value / total
+		if share >= 0.9999 {
+			fmt.Fprintf(&out, `<circle cx="%.2f" cy="%.2f" r="%.2f" fill="%s"><title>%s</title></circle>`+"\n",
+				cx, cy, r, s.Color, tooltip(s.Label, s.Value, share))
+			break
+		}
+
+		end := angle + share*2*math.Pi
+		largeArc := 0
+		if share > 0.5 {
+			largeArc = 1
+		}
+
+		var d string
+		if inner == 0 {
+			d = fmt.Sprintf("M%.2f,%.2f L%.2f,%.2f A%.2f,%.2f 0 %d,1 %.2f,%.2f Z",
+				cx, cy,
+				cx+r*math.Cos(angle), cy+r*math.Sin(angle),
+				r, r, largeArc,
+				cx+r*math.Cos(end), cy+r*math.Sin(end))
+		} else {
+			d = fmt.Sprintf("M%.2f,%.2f A%.2f,%.2f 0 %d,1 %.2f,%.2f L%.2f,%.2f A%.2f,%.2f 0 %d,0 %.2f,%.2f Z",
+				cx+r*math.Cos(angle), cy+r*math.Sin(angle),
+				r, r, largeArc,
+				cx+r*math.Cos(end), cy+r*math.Sin(end),
+				cx+inner*math.Cos(end), cy+inner*math.Sin(end),
+				inner, inner, largeArc,
+				cx+inner*math.Cos(angle), cy+inner*math.Sin(angle))
+		}
+		fmt.Fprintf(&out, `<path d="%s" fill="%s" stroke="#fff" stroke-width="1"><title>%s</title></path>`+"\n",
+			d, s.Color, tooltip(s.Label, s.Value, share))
+		angle = end
+	}
+
+	// Punch the hole (also covers the single full-circle slice)
+	if inner > 0 {
+		fmt.Fprintf(&out, `<circle cx="%.2f" cy="%.2f" r="%.2f" fill="#fff"/>`+"\n", cx, cy, inner)
+		if caption != "" {
+			fmt.Fprintf(&out, `<text x="%.2f" y="%.2f" text-anchor="middle" class="title">%s</text>`+"\n",
+				cx, cy+5, html.EscapeString(caption))
+		}
+	}
+
+	writeLegend(&out, float64(size+16), 34, slices, total)
+	out.WriteString("</svg>\n")
+	return out.String()
+}
+
+// Bars renders a horizontal bar chart as a standalone SVG element
+func Bars(title string, bars []Slice, width int) string {
+	maxValue := 0.0
+	for _, b := range bars {
+		maxValue = math.Max(maxValue, b.Value)
+	}
+
+	labelWidth, valueWidth, rowHeight := 150.0, 70.0, 22.0
+	height := 34 + int(rowHeight)*len(bars)
+
+	var out strings.Builder
+	openSVG(&out, width, height, title)
+	writeTitle(&out, title)
+
+	barSpace := float64(width) - labelWidth - valueWidth
+	for i, b := range bars {
+		y := 30 + float64(i)*rowHeight
+		length := 0.0
+		if maxValue > 0 {
+			length = b.Value / maxValue * barSpace
+		}
+		fmt.Fprintf(&out, `<text x="%.0f" y="%.1f" text-anchor="end" class="label">%s</text>`+"\n",
+			labelWidth-8, y+14, html.EscapeString(b.Label))
+		fmt.Fprintf(&out, `<rect x="%.0f" y="%.1f" width="%.2f" height="%.0f" fill="%s"><title>%s</title></rect>`+"\n",
+			labelWidth, y+2, length, rowHeight-6, b.Color, html.EscapeString(b.Label))
+		fmt.Fprintf(&out, `<text x="%.2f" y="%.1f" class="value">%s</text>`+"\n",
+			labelWidth+length+6, y+14, formatValue(b.Value))
+	}
+
+	out.WriteString("</svg>\n")
+	return out.String()
+}
+
+// StackedArea renders series stacked on top of each other across the x
+// labels (e.g. periods). With percent set, each column is scaled to 100%.
+func StackedArea(title string, labels []string, series []Series, width, height int, percent bool) string {
+	left, right, top, bottom := 56.0, 170.0, 32.0, 40.0
+	plotW := float64(width) - left - right
+	plotH := float64(height) - top - bottom
+
+	totals := make([]float64, len(labels))
+	for _, s := range series {
+		for i := range labels {
+			if i < len(s.Values) {
+				totals[i] += s.Values[i]
+			}
+		}
+	}
+	maxTotal := 0.0
+	for _, t := range totals {
+		maxTotal = math.Max(maxTotal, t)
+	}
+	if percent {
+		maxTotal = 100
+	} else {
+		// Values are counts, so grid steps stay whole numbers
+		maxTotal = math.Max(1, niceStep(maxTotal/4)) * 4
+	}
+
+	x := func(i int) float64 {
+		if len(labels) <= 1 {
+			return left + plotW/2
+		}
+		return left + float64(i)*plotW/float64(len(labels)-1)
+	}
+	y := func(v float64) float64 {
+		if maxTotal == 0 {
+			return top + plotH
+		}
+		return top + plotH - v/maxTotal*plotH
+	}
+	value := func(s Series, i int) float64 {
+		if i >= len(s.Values) {
+			return 0
+		}
+		if percent {
+			if totals[i] == 0 {
+				return 0
+			}
+			return s.Values[i] / totals[i] * 100
+		}
+		return s.Values[i]
+	}
+
+	var out strings.Builder
+	openSVG(&out, width, height, title)
+	writeTitle(&out, title)
+
+	writeGrid(&out, left, top, plotW, plotH, maxTotal, percent)
+
+	// Layers, bottom to top
+	base := make([]float64, len(labels))
+	for _, s := range series {
+		var upper, lower []string
+		for i := range labels {
+			v := value(s, i)
+			upper = append(upper, fmt.Sprintf("%.2f,%.2f", x(i), y(base[i]+v)))
+			lower = append([]string{fmt.Sprintf("%.2f,%.2f", x(i), y(base[i]))}, lower...)
+			base[i] += v
+		}
+		fmt.Fprintf(&out, `<polygon points="%s %s" fill="%s" fill-opacity="0.85" stroke="%s"><title>%s</title></polygon>`+"\n",
+			strings.Join(upper, " "), strings.Join(lower, " "), s.Color, s.Color, html.EscapeString(s.Label))
+	}
+
+	writeXLabels(&out, labels, x, top+plotH+18, plotW)
+
+	legend := make([]Slice, 0, len(series))
+	for i := len(series) - 1; i >= 0; i-- {
+		legend = append(legend, Slice{Label: series[i].Label, Color: series[i].Color})
+	}
+	writeLegend(&out, left+plotW+16, top, legend, 0)
+
+	out.WriteString("</svg>\n")
+	return out.String()
+}
+
+// Lines renders one line per series across the x labels, e.g. the number
+// of contributors of each vendor per period
+func Lines(title string, labels []string, series []Series, width, height int) string {
+	left, right, top, bottom := 56.0, 170.0, 32.0, 40.0
+	plotW := float64(width) - left - right
+	plotH := float64(height) - top - bottom
+
+	maxValue := 0.0
+	for _, s := range series {
+		for _, v := range s.Values {
+			maxValue = math.Max(maxValue, v)
+		}
+	}
+	maxValue = math.Max(1, niceStep(maxValue/4)) * 4
+
+	x := func(i int) float64 {
+		if len(labels) <= 1 {
+			return left + plotW/2
+		}
+		return left + float64(i)*plotW/float64(len(labels)-1)
+	}
+	y := func(v float64) float64 {
+		return top + plotH - v/maxValue*plotH
+	}
+
+	var out strings.Builder
+	openSVG(&out, width, height, title)
+	writeTitle(&out, title)
+	writeGrid(&out, left, top, plotW, plotH, maxValue, false)
+
+	for _, s := range series {
+		points := make([]string, 0, len(s.Values))
+		for i, v := range s.Values {
+			points = append(points, fmt.Sprintf("%.2f,%.2f", x(i), y(v)))
+		}
+		fmt.Fprintf(&out, `<polyline points="%s" fill="none" stroke="%s" stroke-width="2"><title>%s</title></polyline>`+"\n",
+			strings.Join(points, " "), s.Color, html.EscapeString(s.Label))
+		for i, v := range s.Values {
+			fmt.Fprintf(&out, `<circle cx="%.2f" cy="%.2f" r="2.5" fill="%s"><title>%s</title></circle>`+"\n",
+				x(i), y(v), s.Color, html.EscapeString(fmt.Sprintf("%s %s: %s", s.Label, labels[i], formatValue(v))))
+		}
+	}
+
+	writeXLabels(&out, labels, x, top+plotH+18, plotW)
+
+	legend := make([]Slice, 0, len(series))
+	for _, s := range series {
+		legend = append(legend, Slice{Label: s.Label, Color: s.Color})
+	}
+	writeLegend(&out, left+plotW+16, top, legend, 0)
+
+	out.WriteString("</svg>\n")
+	return out.String()
+}
+
+// Badge renders a shields-style "label | value" badge
+func Badge(label, value, color string) string {
+	// Approximate Verdana 11px text widths
+	labelWidth := 10 + int(float64(len([]rune(label)))*6.5)
+	valueWidth := 10 + int(float64(len([]rune(value)))*7)
+	width := labelWidth + valueWidth
+
+	var out strings.Builder
+	fmt.Fprintf(&out, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="20" role="img" aria-label="%s: %s">`+"\n",
+		width, html.EscapeString(label), html.EscapeString(value))
+	fmt.Fprintf(&out, `<title>%s: %s</title>`+"\n", html.EscapeString(label), html.EscapeString(value))
+	out.WriteString(`<linearGradient id="s" x2="0" y2="100%"><stop offset="0" stop-color="#bbb" stop-opacity=".1"/><stop offset="1" stop-opacity=".1"/></linearGradient>` + "\n")
+	fmt.Fprintf(&out, `<clipPath id="r"><rect width="%d" height="20" rx="3" fill="#fff"/></clipPath>`+"\n", width)
+	fmt.Fprintf(&out, `<g clip-path="url(#r)"><rect width="%d" height="20" fill="#555"/><rect x="%d" width="%d" height="20" fill="%s"/><rect width="%d" height="20" fill="url(#s)"/></g>`+"\n",
+		labelWidth, labelWidth, valueWidth, color, width)
+	out.WriteString(`<g fill="#fff" text-anchor="middle" font-family="Verdana,Geneva,DejaVu Sans,sans-serif" font-size="11">` + "\n")
+	fmt.Fprintf(&out, `<text x="%.1f" y="15" fill="#010101" fill-opacity=".3">%s</text><text x="%.1f" y="14">%s</text>`+"\n",
+		float64(labelWidth)/2, html.EscapeString(label), float64(labelWidth)/2, html.EscapeString(label))
+	fmt.Fprintf(&out, `<text x="%.1f" y="15" fill="#010101" fill-opacity=".3">%s</text><text x="%.1f" y="14">%s</text>`+"\n",
+		float64(labelWidth)+float64(valueWidth)/2, html.EscapeString(value), float64(labelWidth)+float64(valueWidth)/2, html.EscapeString(value))
+	out.WriteString("</g>\n</svg>\n")
+	return out.String()
+}
+
+// writeGrid writes horizontal grid lines with y-axis labels
+func writeGrid(out *strings.Builder, left, top, plotW, plotH, maxValue float64, percent bool) {
+	for t := 0; t <= 4; t++ {
+		v := maxValue * float64(t) / 4
+		gy := top + plotH - float64(t)/4*plotH
+		fmt.Fprintf(out, `<line x1="%.0f" y1="%.2f" x2="%.2f" y2="%.2f" class="grid"/>`+"\n", left, gy, left+plotW, gy)
+		label := formatValue(v)
+		if percent {
+			label += "%"
+		}
+		fmt.Fprintf(out, `<text x="%.0f" y="%.2f" text-anchor="end" class="axis">%s</text>`+"\n", left-6, gy+4, label)
+	}
+}
+
+// writeXLabels writes x-axis labels, thinned out so they do not overlap
+func writeXLabels(out *strings.Builder, labels []string, x func(int) float64, y, plotW float64) {
+	step := int(math.Ceil(float64(len(labels)) * 60 / plotW))
+	if step < 1 {
+		step = 1
+	}
+	for i, label := range labels {
+		if i%step != 0 {
+			continue
+		}
+		fmt.Fprintf(out, `<text x="%.2f" y="%.2f" text-anchor="middle" class="axis">%s</text>`+"\n",
+			x(i), y, html.EscapeString(label))
+	}
+}
+
+// niceStep rounds a grid step up to 1, 2, 2.5 or 5 times a power of ten
+func niceStep(step float64) float64 {
+	if step <= 0 {
+		return 0
+	}
+	magnitude := math.Pow(10, math.Floor(math.Log10(step)))
+	for _, m := range []float64{1, 2, 2.5, 5, 10} {
+		if m*magnitude >= step {
+			return m * magnitude
+		}
+	}
+	return 10 * magnitude
+}
+
+// SortSlices orders slices by value (descending), then label
+func SortSlices(slices []Slice) {
+	sort.SliceStable(slices, func(i, j int) bool {
+		if slices[i].Value != slices[j].Value {
+			return slices[i].Value > slices[j].Value
+		}
+		return slices[i].Label < slices[j].Label
+	})
+}
+
+// openSVG writes the root element with the shared text styles
+func openSVG(out *strings.Builder, width, height int, title string) {
+	fmt.Fprintf(out, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d" role="img" aria-label="%s">`+"\n",
+		width, height, width, height, html.EscapeString(title))
+	out.WriteString(`<style>text{font-family:-apple-system,"Segoe UI",Helvetica,Arial,sans-serif;font-size:12px;fill:#333}` +
+		`.title{font-size:14px;font-weight:600}.axis{font-size:11px;fill:#666}.grid{stroke:#e5e5e5}` +
+		`.value{font-size:11px;fill:#555}</style>` + "\n")
+}
+
+// writeTitle writes the chart title
+func writeTitle(out *strings.Builder, title string) {
+	if title != "" {
+		fmt.Fprintf(out, `<text x="4" y="16" class="title">%s</text>`+"\n", html.EscapeString(title))
+	}
+}
+
+// writeLegend writes one swatch per slice; a non-zero total adds percentages
+func writeLegend(out *strings.Builder, x, y float64, slices []Slice, total float64) {
+	for i, s := range slices {
+		ly := y + float64(i)*18
+		label := s.Label
+		if total > 0 {
+			label = fmt.Sprintf("%s (%.1f%%)", s.Label, s.Value/total*100)
+		}
+		fmt.Fprintf(out, `<rect x="%.0f" y="%.0f" width="12" height="12" fill="%s"/>`+"\n", x, ly, s.Color)
+		fmt.Fprintf(out, `<text x="%.0f" y="%.0f">%s</text>`+"\n", x+18, ly+10, html.EscapeString(label))
+	}
+}
+
+// tooltip formats the hover text of a pie slice
+func tooltip(label string, value, share float64) string {
+	return html.EscapeString(fmt.Sprintf("%s: %s (%.1f%%)", label, formatValue(value), share*100))
+}
+
+// formatValue prints whole numbers without decimals and with thousands separators
+func formatValue(v float64) string {
+	if v != math.Trunc(v) {
+		return fmt.Sprintf("%.1f", v)
+	}
+
+	s := fmt.Sprintf("%d", int64(v))
+	var result []byte
+	for i := range s {
+		if i > 0 && (len(s)-i)%3 == 0 && s[i-1] != '-' {
+			result = append(result, ',')
+		}
+		result = append(result, s[i])
+	}
+	return string(result)
+}
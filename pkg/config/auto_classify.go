@@ -4,49 +4,95 @@ import (
 	"strings"
 )
 
-// AutoClassifyByDomain automatically classifies contributors by email domain
-// when no vendor config is provided
-func AutoClassifyByDomain(email string) string {
+// personalDomains indexes the built-in personal provider list
+var personalDomains = newDomainSet(defaultPersonalDomains)
+
+// domainSet matches domains against exact entries and globs
+type domainSet struct {
+	exact     map[string]bool
+	wildcards []string
+}
+
+// newDomainSet builds a domainSet from exact domains and globs
+func newDomainSet(domains []string) *domainSet {
+	s := &domainSet{exact: make(map[string]bool, len(domains))}
+	s.add(domains)
+	return s
+}
+
+// add inserts domains (or globs such as "*.yahoo.com") into the set
+func (s *domainSet) add(domains []string) {
+	for _, d := range domains {
+		d = strings.ToLower(strings.TrimSpace(d))
+		if isWildcard(d) {
+			s.wildcards = append(s.wildcards, d)
+		} else if d != "" {
+			s.exact[d] = true
+		}
+	}
+}
+
+// contains reports whether domain is in the set
+func (s *domainSet) contains(domain string) bool {
+	domain = strings.ToLower(domain)
+	if s.exact[domain] {
+		return true
+	}
+	for _, pattern := range s.wildcards {
+		if matchDomain(pattern, domain) {
+			return true
+		}
+	}
+	return false
+}
+
+// EmailDomain returns the lowercased domain part of an email address,
+// or "" when the address is not of the form local@domain
+func EmailDomain(email string) string {
+	parts := strings.Split(email, "@")
+	if len(parts) != 2 {
+		return ""
+	}
+	return strings.ToLower(parts[1])
+}
+
+// IsPersonalDomain reports whether a domain is in the built-in list of
+// personal email providers
+func IsPersonalDomain(domain string) bool {
+	return personalDomains.contains(domain)
+}
+
+// IsPersonalDomain reports whether a domain is a personal email provider
+// according to the built-in list as extended or replaced by the config
+func (c *Config) IsPersonalDomain(domain string) bool {
+	c.ensureCompiled()
+	return c.personal.contains(domain)
+}
+
+// AddPersonalDomains extends the personal provider list, or replaces the
+// built-in list when replace is set (configured domains are kept)
+func (c *Config) AddPersonalDomains(domains []string, replace bool) {
+	c.PersonalDomains.Domains = append(c.PersonalDomains.Domains, domains...)
+	if replace {
+		c.PersonalDomains.Replace = true
+	}
+	c.compiled = false
+}
+
+// autoClassify classifies by email domain using the given personal provider check
+func autoClassify(email string, isPersonal func(string) bool) string {
 	if email == "" {
 		return "unknown"
 	}
 
 	// Extract domain from email
-	parts := strings.Split(email, "@")
-	if len(parts) != 2 {
+	domain := EmailDomain(email)
+	if domain == "" {
 		return "invalid-email"
 	}
 
-	domain := strings.ToLower(parts[1])
-
-	// Group common personal email providers as "community"
-	personalDomains := map[string]bool{
-		"gmail.com":                true,
-		"yahoo.com":                true,
-		"hotmail.com":              true,
-		"outlook.com":              true,
-		"protonmail.com":           true,
-		"icloud.com":               true,
-		"mail.com":                 true,
-		"aol.com":                  true,
-		"yandex.com":               true,
-		"qq.com":                   true,
-		"163.com":                  true,
-		"126.com":                  true,
-		"sina.com":                 true,
-		"live.com":                 true,
-		"msn.com":                  true,
-		"me.com":                   true,
-		"mac.com":                  true,
-		"googlemail.com":           true,
-		"yahoo.co.uk":              true,
-		"yahoo.co.jp":              true,
-		"fastmail.com":             true,
-		"zoho.com":                 true,
-		"users.noreply.github.com": true, // GitHub privacy emails
-	}
-
-	if personalDomains[domain] {
+	// Group personal email providers as "community"
+	if isPersonal(domain) {
 		return "community"
 	}
 
@@ -0,0 +1,129 @@
+package config
+
+import (
+	"sort"
+	"strings"
+)
+
+// Built-in auto-classification categories
+const (
+	CategoryAcademia   = "academia"
+	CategoryFoundation = "foundation"
+)
+
+// defaultCategories lists the domains of the built-in categories, which
+// separate research and foundation-account contributions from vendors
+var defaultCategories = map[string][]string{
+	CategoryAcademia: {
+		// US and international academic second-level domains
+		"*.edu", "*.edu.au", "*.edu.br", "*.edu.cn", "*.edu.hk", "*.edu.in", "*.edu.mx",
+		"*.edu.pl", "*.edu.sg", "*.edu.tr", "*.edu.tw", "*.edu.ar", "*.edu.co",
+		"*.ac.uk", "*.ac.jp", "*.ac.kr", "*.ac.in", "*.ac.nz", "*.ac.za", "*.ac.at",
+		"*.ac.il", "*.ac.cn", "*.ac.be", "*.ac.id", "*.ac.th",
+		// German, French and Swiss universities and research institutes
+		"uni-*.de", "*.uni-*.de", "tu-*.de", "*.tu-*.de", "tum.de", "*.tum.de",
+		"rwth-aachen.de", "*.rwth-aachen.de", "fraunhofer.de", "*.fraunhofer.de", "mpg.de", "*.mpg.de",
+		"univ-*.fr", "*.univ-*.fr", "inria.fr", "*.inria.fr", "cnrs.fr", "*.cnrs.fr",
+		"ethz.ch", "*.ethz.ch", "epfl.ch", "*.epfl.ch", "unibe.ch", "uzh.ch", "*.uzh.ch",
+		// Other well-known research universities
+		"ox.ac.uk", "cam.ac.uk", "utoronto.ca", "*.utoronto.ca", "ubc.ca", "*.ubc.ca",
+		"mcgill.ca", "uwaterloo.ca", "*.uwaterloo.ca", "kth.se", "*.kth.se", "tudelft.nl",
+		"*.tudelft.nl", "uva.nl", "vu.nl", "kuleuven.be", "*.kuleuven.be", "polimi.it",
+		"*.polimi.it", "unimi.it", "tsinghua.edu.cn", "nus.edu.sg", "u-tokyo.ac.jp", "cern.ch",
+	},
+	CategoryFoundation: {
+		"apache.org", "eclipse.org", "eclipse-foundation.org", "linuxfoundation.org",
+		"cncf.io", "openjsf.org", "lfai.foundation", "lfenergy.org", "hyperledger.org",
+		"openinfra.dev", "openstack.org", "python.org", "numfocus.org", "rust-lang.org",
+		"kernel.org", "freedesktop.org", "x.org", "gnome.org", "kde.org", "debian.org",
+		"freebsd.org", "openbsd.org", "netbsd.org", "fsf.org", "gnu.org", "opensource.org",
+		"sfconservancy.org", "spi-inc.org", "osgeo.org", "ow2.org", "php.net", "perl.org",
+		"blender.org", "videolan.org", "wikimedia.org",
+	},
+}
+
+// CategoryConfig customizes a built-in category or defines an additional one
+type CategoryConfig struct {
+	Domains  []string `yaml:"domains,omitempty"`  // exact domains or globs
+	Replace  bool     `yaml:"replace,omitempty"`  // drop the built-in domains of this category
+	Disabled bool     `yaml:"disabled,omitempty"` // turn the category off
+}
+
+// categoryRule is a compiled category
+type categoryRule struct {
+	name    string
+	domains *domainSet
+}
+
+// compileCategories merges the built-in categories with the configured ones
+func (c *Config) compileCategories() []categoryRule {
+	names := make(map[string]bool)
+	for name := range defaultCategories {
+		names[name] = true
+	}
+	for name := range c.Categories {
+		names[name] = true
+	}
+
+	sorted := make([]string, 0, len(names))
+	for name := range names {
+		sorted = append(sorted, name)
+	}
+	sort.Strings(sorted)
+
+	rules := make([]categoryRule, 0, len(sorted))
+	for _, name := range sorted {
+		custom := c.Categories[name]
+		if custom.Disabled {
+			continue
+		}
+
+		domains := newDomainSet(nil)
+		if !custom.Replace {
+			domains.add(defaultCategories[name])
+		}
+		domains.add(custom.Domains)
+		rules = append(rules, categoryRule{name: name, domains: domains})
+	}
+	return rules
+}
+
+// categoriesEnabled reports whether categories apply: always in automatic
+// mode, and in configured mode only when the config has a categories section
+func (c *Config) categoriesEnabled() bool {
+	return len(c.Vendors) == 0 || len(c.Categories) > 0
+}
+
+// categoryMatches returns every category whose domains include domain.
+// Exact entries rank before globs; categories are taken alphabetically.
+func (c *Config) categoryMatches(domain string) []Match {
+	c.ensureCompiled()
+
+	var exact, wildcard []Match
+	for _, rule := range c.categories {
+		if rule.domains.exact[domain] {
+			exact = append(exact, Match{Rule: RuleCategory, Category: rule.name, Pattern: domain})
+			continue
+		}
+		for _, pattern := range rule.domains.wildcards {
+			if matchDomain(pattern, domain) {
+				wildcard = append(wildcard, Match{Rule: RuleCategory, Category: rule.name, Pattern: pattern})
+				break
+			}
+		}
+	}
+	return append(exact, wildcard...)
+}
+
+// ClassifyByCategory classifies an email into a built-in or configured
+// category such as "academia" or "foundation", returning "" when none applies
+func (c *Config) ClassifyByCategory(email string) string {
+	domain := EmailDomain(email)
+	if domain == "" || !c.categoriesEnabled() || c.IsPersonalDomain(domain) {
+		return ""
+	}
+	if matches := c.categoryMatches(strings.ToLower(domain)); len(matches) > 0 {
+		return matches[0].Category
+	}
+	return ""
+}
@@ -1,47 +1,135 @@
 package config
 
 import (
-	"os"
+	"regexp"
+	"sort"
 	"strings"
+	"time"
 
-	"gopkg.in/yaml.v3"
+	"github.com/sderosiaux/git-contributor-insights/pkg/palette"
 )
 
 // VendorConfig represents configuration for identifying a vendor
 type VendorConfig struct {
-	Domains         []string `yaml:"domains"`
+	// Domains lists exact email domains or globs such as "*.ibm.com"
+	Domains []string `yaml:"domains"`
+	// DomainPatterns lists regular expressions matched against the whole domain
+	DomainPatterns []string `yaml:"domain_patterns,omitempty"`
+	// ExcludeDomains lists domains (or globs) this vendor never matches
+	ExcludeDomains  []string `yaml:"exclude_domains,omitempty"`
 	GithubCompanies []string `yaml:"github_companies"`
+	// Priority decides between vendors whose rules overlap (higher wins,
+	// ties resolve alphabetically by vendor name)
+	Priority int `yaml:"priority,omitempty"`
+	// Parent names the owning company (e.g. linkedin -> microsoft), used by rollups
+	Parent string `yaml:"parent,omitempty"`
+	// Color pins the vendor's color in every display and chart ("#rrggbb")
+	Color string `yaml:"color,omitempty"`
 }
 
 // Config represents the complete configuration file
 type Config struct {
+	// Include lists base files merged before this one (paths relative to this file)
+	Include []string                `yaml:"include,omitempty"`
 	Vendors map[string]VendorConfig `yaml:"vendors"`
+	// Identities assigns specific people to a vendor, overriding domain and company rules
+	Identities []IdentityConfig `yaml:"identities,omitempty"`
+	// PersonalDomains extends or replaces the built-in personal email provider list
+	PersonalDomains PersonalDomainsConfig `yaml:"personal_domains,omitempty"`
+	// Categories customizes the built-in academia/foundation buckets or adds new ones
+	Categories map[string]CategoryConfig `yaml:"categories,omitempty"`
+
+	// explicit lists the scalar settings written in the files (see explicitKeys)
+	explicit map[string]bool
+
+	compiled   bool
+	patterns   map[string][]*regexp.Regexp // compiled domain_patterns per vendor
+	identities []compiledIdentity
+	personal   *domainSet
+	categories []categoryRule
 }
 
-// Load loads configuration from a YAML file
-func Load(path string) (*Config, error) {
-	data, err := os.ReadFile(path)
-	if err != nil {
-		return nil, err
-	}
+// PersonalDomainsConfig customizes which email providers count as personal.
+// Personal domains are classified as "community" and never match a vendor
+// domain rule.
+type PersonalDomainsConfig struct {
+	Domains []string `yaml:"domains,omitempty"` // exact domains or globs
+	Replace bool     `yaml:"replace,omitempty"` // drop the built-in list instead of extending it
+}
 
-	var config Config
-	if err := yaml.Unmarshal(data, &config); err != nil {
-		return nil, err
-	}
+// Contributor holds the signals used to classify a commit author
+type Contributor struct {
+	Name    string
+	Email   string
+	Company string
+	Date    time.Time // commit date, used by time-bounded identities (zero ignores bounds)
+}
 
-	return &config, nil
+// Load loads configuration from a YAML file, merging any included files
+func Load(path string) (*Config, error) {
+	return LoadAll(path)
 }
 
-// GetVendorNames returns a list of all configured vendor names
+// GetVendorNames returns a list of all configured vendor names, sorted alphabetically
 func (c *Config) GetVendorNames() []string {
 	names := make([]string, 0, len(c.Vendors))
 	for name := range c.Vendors {
 		names = append(names, name)
 	}
+	sort.Strings(names)
 	return names
 }
 
+// vendorsByPriority returns vendor names in evaluation order: highest
+// priority first, then alphabetically, so classification is deterministic
+func (c *Config) vendorsByPriority() []string {
+	names := c.GetVendorNames()
+	sort.SliceStable(names, func(i, j int) bool {
+		return c.Vendors[names[i]].Priority > c.Vendors[names[j]].Priority
+	})
+	return names
+}
+
+// ParentOf returns the top-level company owning a vendor by following
+// parent links. Vendors without a parent (and categories) are their own
+// parent, as are vendors whose parent chain forms a cycle.
+func (c *Config) ParentOf(vendor string) string {
+	seen := map[string]bool{vendor: true}
+	current := vendor
+	for {
+		parent := c.Vendors[current].Parent
+		if parent == "" {
+			return current
+		}
+		if seen[parent] {
+			return vendor
+		}
+		seen[parent] = true
+		current = parent
+	}
+}
+
+// HasParents reports whether any vendor declares a parent company
+func (c *Config) HasParents() bool {
+	for _, vendor := range c.Vendors {
+		if vendor.Parent != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// VendorColors returns the colors pinned by vendors, ignoring malformed ones
+func (c *Config) VendorColors() map[string]string {
+	colors := make(map[string]string)
+	for name, vendor := range c.Vendors {
+		if palette.IsHex(vendor.Color) {
+			colors[name] = strings.ToLower(vendor.Color)
+		}
+	}
+	return colors
+}
+
 // GetAllCategories returns all possible categories (vendors + community)
 func (c *Config) GetAllCategories() []string {
 	categories := c.GetVendorNames()
@@ -49,24 +137,16 @@ func (c *Config) GetAllCategories() []string {
 }
 
 // ClassifyByEmail classifies a contributor by email domain
+// Precedence: exact domain > wildcard domain > domain pattern
+// Personal email providers never match a vendor.
 func (c *Config) ClassifyByEmail(email string) string {
-	if email == "" {
-		return ""
-	}
-
-	parts := strings.Split(email, "@")
-	if len(parts) != 2 {
+	domain := EmailDomain(email)
+	if domain == "" || c.IsPersonalDomain(domain) {
 		return ""
 	}
 
-	domain := strings.ToLower(parts[1])
-
-	for vendorName, vendor := range c.Vendors {
-		for _, d := range vendor.Domains {
-			if strings.ToLower(d) == domain {
-				return vendorName
-			}
-		}
+	if matches := c.domainMatches(domain); len(matches) > 0 {
+		return matches[0].Vendor
 	}
 
 	return ""
@@ -80,8 +160,8 @@ func (c *Config) ClassifyByCompany(company string) string {
 
 	companyLower := strings.ToLower(strings.TrimSpace(company))
 
-	for vendorName, vendor := range c.Vendors {
-		for _, vendorCompany := range vendor.GithubCompanies {
+	for _, vendorName := range c.vendorsByPriority() {
+		for _, vendorCompany := range c.Vendors[vendorName].GithubCompanies {
 			if strings.Contains(companyLower, strings.ToLower(vendorCompany)) {
 				return vendorName
 			}
@@ -91,25 +171,44 @@ func (c *Config) ClassifyByCompany(company string) string {
 	return ""
 }
 
-// Classify classifies a contributor using all available signals
-// Priority: email > company > community
-// When no vendors are configured, automatically classifies by email domain
+// Classify classifies a contributor by email and GitHub company
 func (c *Config) Classify(email, company string) string {
+	return c.ClassifyContributor(Contributor{Email: email, Company: company})
+}
+
+// ClassifyContributor classifies a contributor using all available signals
+// Priority: identity > email (unless personal) > company > category > community
+// When no vendors are configured, automatically classifies by email domain
+// (personal > category > "@domain")
+func (c *Config) ClassifyContributor(who Contributor) string {
+	// Per-person overrides win over every other rule
+	if vendor := c.ClassifyByIdentity(who); vendor != "" {
+		return vendor
+	}
+
 	// If no vendors configured, use automatic domain classification
 	if len(c.Vendors) == 0 {
-		return AutoClassifyByDomain(email)
+		if category := c.ClassifyByCategory(who.Email); category != "" {
+			return category
+		}
+		return autoClassify(who.Email, c.IsPersonalDomain)
 	}
 
 	// Try email domain
-	if vendor := c.ClassifyByEmail(email); vendor != "" {
+	if vendor := c.ClassifyByEmail(who.Email); vendor != "" {
 		return vendor
 	}
 
 	// Try company field
-	if vendor := c.ClassifyByCompany(company); vendor != "" {
+	if vendor := c.ClassifyByCompany(who.Company); vendor != "" {
 		return vendor
 	}
 
+	// Try academia/foundation categories (when the config enables them)
+	if category := c.ClassifyByCategory(who.Email); category != "" {
+		return category
+	}
+
 	// Default to community
 	return "community"
 }
@@ -0,0 +1,112 @@
+package config
+
+import (
+	"strings"
+)
+
+// Rule kinds reported by Explain
+const (
+	RuleIdentity       = "identity"        // identities section (per-person override)
+	RuleDomain         = "domain"          // vendor domains list
+	RuleDomainWildcard = "domain-wildcard" // vendor domains glob such as "*.ibm.com"
+	RuleDomainPattern  = "domain-pattern"  // vendor domain_patterns regular expression
+	RuleCompany        = "company"         // vendor github_companies list
+	RuleCategory       = "category"        // academia/foundation (or configured) category domains
+	RulePersonalDomain = "personal-domain" // personal email provider list
+	RuleAutoDomain     = "auto-domain"     // corporate domain reported as "@domain" (auto-classify)
+	RuleFallback       = "fallback"        // nothing matched
+)
+
+// Match describes a single classification rule that applies to a contributor
+type Match struct {
+	Rule     string // one of the Rule* constants
+	Category string // category the rule assigns
+	Vendor   string // configured vendor owning the rule, if any
+	Pattern  string // configured value that matched (domain, company substring)
+}
+
+// Explanation describes how a contributor was classified
+type Explanation struct {
+	Email        string
+	Company      string
+	Category     string
+	Parent       string  // top-level company owning the category, if different
+	Matched      Match   // rule that decided the category
+	Alternatives []Match // other rules that also matched but lost on priority
+}
+
+// Explain classifies a contributor like ClassifyContributor and reports the
+// rule that decided the category along with every other rule that would have matched
+func (c *Config) Explain(who Contributor) *Explanation {
+	exp := &Explanation{
+		Email:    who.Email,
+		Company:  who.Company,
+		Category: c.ClassifyContributor(who),
+	}
+	if parent := c.ParentOf(exp.Category); parent != exp.Category {
+		exp.Parent = parent
+	}
+
+	matches := c.allMatches(who)
+	for i, m := range matches {
+		if m.Category == exp.Category {
+			exp.Matched = m
+			exp.Alternatives = append(append([]Match{}, matches[:i]...), matches[i+1:]...)
+			return exp
+		}
+	}
+
+	exp.Matched = Match{Rule: RuleFallback, Category: exp.Category}
+	exp.Alternatives = matches
+	return exp
+}
+
+// allMatches returns every rule matching the contributor in precedence order
+func (c *Config) allMatches(who Contributor) []Match {
+	matches := c.identityMatches(who)
+	domain := EmailDomain(who.Email)
+	company := who.Company
+
+	// Without vendors only the auto-classification rules apply
+	if len(c.Vendors) == 0 {
+		switch {
+		case domain == "":
+			// Classified as "unknown" or "invalid-email" by the fallback
+		case c.IsPersonalDomain(domain):
+			matches = append(matches, Match{Rule: RulePersonalDomain, Category: "community", Pattern: domain})
+		default:
+			matches = append(matches, c.categoryMatches(domain)...)
+			matches = append(matches, Match{Rule: RuleAutoDomain, Category: "@" + domain, Pattern: domain})
+		}
+		return matches
+	}
+
+	names := c.vendorsByPriority()
+
+	personal := domain != "" && c.IsPersonalDomain(domain)
+	if domain != "" && !personal {
+		matches = append(matches, c.domainMatches(domain)...)
+	}
+
+	if company != "" {
+		companyLower := strings.ToLower(strings.TrimSpace(company))
+		for _, name := range names {
+			for _, vc := range c.Vendors[name].GithubCompanies {
+				if strings.Contains(companyLower, strings.ToLower(vc)) {
+					matches = append(matches, Match{Rule: RuleCompany, Category: name, Vendor: name, Pattern: vc})
+				}
+			}
+		}
+	}
+
+	if domain != "" && !personal && c.categoriesEnabled() {
+		matches = append(matches, c.categoryMatches(domain)...)
+	}
+
+	// Personal providers fall back to community after identity and company rules
+	if personal {
+		matches = append(matches, Match{Rule: RulePersonalDomain, Category: "community", Pattern: domain})
+	}
+
+	return matches
+}
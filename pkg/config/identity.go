@@ -0,0 +1,143 @@
+package config
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// IdentityConfig assigns a specific person to a vendor, taking precedence
+// over domain and company rules. An identity matches when any of its emails
+// or names match (case-insensitively) or its name pattern matches.
+type IdentityConfig struct {
+	Emails      []string `yaml:"emails,omitempty"`
+	Names       []string `yaml:"names,omitempty"`
+	NamePattern string   `yaml:"name_pattern,omitempty"`
+	Vendor      string   `yaml:"vendor"`
+	From        string   `yaml:"from,omitempty"`  // first day the affiliation applies (YYYY-MM-DD)
+	Until       string   `yaml:"until,omitempty"` // last day the affiliation applies (YYYY-MM-DD)
+}
+
+// compiledIdentity is an IdentityConfig with parsed dates and pattern
+type compiledIdentity struct {
+	IdentityConfig
+	namePattern *regexp.Regexp
+	from        time.Time
+	until       time.Time // exclusive: start of the day after Until
+}
+
+// describe returns a short label identifying the identity in messages
+func (id IdentityConfig) describe() string {
+	switch {
+	case len(id.Emails) > 0:
+		return id.Emails[0]
+	case len(id.Names) > 0:
+		return id.Names[0]
+	case id.NamePattern != "":
+		return "/" + id.NamePattern + "/"
+	default:
+		return "empty identity"
+	}
+}
+
+// compileIdentity parses an identity's dates and name pattern
+func compileIdentity(id IdentityConfig) (compiledIdentity, error) {
+	compiled := compiledIdentity{IdentityConfig: id}
+
+	if id.Vendor == "" {
+		return compiled, fmt.Errorf("missing vendor")
+	}
+	if len(id.Emails) == 0 && len(id.Names) == 0 && id.NamePattern == "" {
+		return compiled, fmt.Errorf("needs at least one of emails, names or name_pattern")
+	}
+
+	if id.NamePattern != "" {
+		re, err := regexp.Compile("(?i)" + id.NamePattern)
+		if err != nil {
+			return compiled, fmt.Errorf("invalid name_pattern %q: %w", id.NamePattern, err)
+		}
+		compiled.namePattern = re
+	}
+
+	if id.From != "" {
+		t, err := time.Parse("2006-01-02", id.From)
+		if err != nil {
+			return compiled, fmt.Errorf("invalid from date %q (want YYYY-MM-DD)", id.From)
+		}
+		compiled.from = t
+	}
+
+	if id.Until != "" {
+		t, err := time.Parse("2006-01-02", id.Until)
+		if err != nil {
+			return compiled, fmt.Errorf("invalid until date %q (want YYYY-MM-DD)", id.Until)
+		}
+		compiled.until = t.AddDate(0, 0, 1)
+	}
+
+	if !compiled.from.IsZero() && !compiled.until.IsZero() && !compiled.from.Before(compiled.until) {
+		return compiled, fmt.Errorf("from date %s is after until date %s", id.From, id.Until)
+	}
+
+	return compiled, nil
+}
+
+// match returns the email, name or pattern that matched the contributor
+func (ci compiledIdentity) match(who Contributor) (string, bool) {
+	if !who.Date.IsZero() {
+		if !ci.from.IsZero() && who.Date.Before(ci.from) {
+			return "", false
+		}
+		if !ci.until.IsZero() && !who.Date.Before(ci.until) {
+			return "", false
+		}
+	}
+
+	if who.Email != "" {
+		for _, email := range ci.Emails {
+			if strings.EqualFold(strings.TrimSpace(email), who.Email) {
+				return email, true
+			}
+		}
+	}
+
+	if who.Name != "" {
+		for _, name := range ci.Names {
+			if strings.EqualFold(strings.TrimSpace(name), who.Name) {
+				return name, true
+			}
+		}
+		if ci.namePattern != nil && ci.namePattern.MatchString(who.Name) {
+			return ci.NamePattern, true
+		}
+	}
+
+	return "", false
+}
+
+// identityMatches returns every identity rule matching the contributor, in
+// the order they are listed in the configuration
+func (c *Config) identityMatches(who Contributor) []Match {
+	c.ensureCompiled()
+
+	matches := make([]Match, 0)
+	for _, ci := range c.identities {
+		if pattern, ok := ci.match(who); ok {
+			matches = append(matches, Match{Rule: RuleIdentity, Category: ci.Vendor, Vendor: ci.Vendor, Pattern: pattern})
+		}
+	}
+	return matches
+}
+
+// ClassifyByIdentity classifies a contributor by the identities section,
+// returning "" when no identity matches. The first listed identity wins.
+func (c *Config) ClassifyByIdentity(who Contributor) string {
+	if len(c.Identities) == 0 {
+		return ""
+	}
+	if matches := c.identityMatches(who); len(matches) > 0 {
+		return matches[0].Vendor
+	}
+	return ""
+}
@@ -0,0 +1,222 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// configFile is one file read while resolving includes
+type configFile struct {
+	path string
+	data []byte
+}
+
+// LoadAll loads several configuration files and merges them in order, so
+// later files extend and override earlier ones (like repeated --config flags)
+func LoadAll(paths ...string) (*Config, error) {
+	merged := &Config{}
+	for _, path := range paths {
+		cfg, _, err := loadTree(path, nil)
+		if err != nil {
+			return nil, err
+		}
+		merged.Merge(cfg)
+	}
+
+	if err := merged.compile(); err != nil {
+		return nil, err
+	}
+	return merged, nil
+}
+
+// loadTree reads a configuration file and the files it includes. Included
+// files are merged first, in order, and the including file is merged last.
+// stack holds the absolute paths currently being loaded, to detect cycles.
+func loadTree(path string, stack []string) (*Config, []configFile, error) {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return nil, nil, err
+	}
+	for i, p := range stack {
+		if p == abs {
+			chain := append(append([]string{}, stack[i:]...), abs)
+			return nil, nil, fmt.Errorf("include cycle: %s", strings.Join(chain, " -> "))
+		}
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var own Config
+	if err := yaml.Unmarshal(data, &own); err != nil {
+		return nil, nil, fmt.Errorf("%s: %w", path, err)
+	}
+	own.explicit = explicitKeys(data)
+
+	merged := &Config{}
+	files := make([]configFile, 0, 1+len(own.Include))
+	for _, include := range own.Include {
+		if !filepath.IsAbs(include) {
+			include = filepath.Join(filepath.Dir(path), include)
+		}
+
+		cfg, included, err := loadTree(include, append(stack, abs))
+		if err != nil {
+			return nil, nil, fmt.Errorf("%s: %w", path, err)
+		}
+		merged.Merge(cfg)
+		files = append(files, included...)
+	}
+
+	merged.Merge(&own)
+	files = append(files, configFile{path: path, data: data})
+	return merged, files, nil
+}
+
+// Merge layers another configuration on top of this one: vendors are
+// extended (list fields unioned), scalar settings such as priority, parent,
+// color and the replace/disabled switches are overridden whenever other sets
+// them (even to a zero value), identities from other take precedence, and
+// personal domains and categories are unioned.
+func (c *Config) Merge(other *Config) {
+	if c.Vendors == nil {
+		c.Vendors = make(map[string]VendorConfig)
+	}
+	for name, vendor := range other.Vendors {
+		base, ok := c.Vendors[name]
+		if !ok {
+			c.Vendors[name] = vendor
+			continue
+		}
+
+		key := "vendors." + name + "."
+		base.Domains = unionStrings(base.Domains, vendor.Domains)
+		base.DomainPatterns = unionStrings(base.DomainPatterns, vendor.DomainPatterns)
+		base.ExcludeDomains = unionStrings(base.ExcludeDomains, vendor.ExcludeDomains)
+		base.GithubCompanies = unionStrings(base.GithubCompanies, vendor.GithubCompanies)
+		if other.sets(key+"priority", vendor.Priority != 0) {
+			base.Priority = vendor.Priority
+		}
+		if other.sets(key+"parent", vendor.Parent != "") {
+			base.Parent = vendor.Parent
+		}
+		if other.sets(key+"color", vendor.Color != "") {
+			base.Color = vendor.Color
+		}
+		c.Vendors[name] = base
+	}
+
+	// The first matching identity wins, so later files go first. Files
+	// included along several paths contribute their identities once.
+	if len(other.Identities) > 0 {
+		c.Identities = uniqueIdentities(append(append([]IdentityConfig{}, other.Identities...), c.Identities...))
+	}
+
+	c.PersonalDomains.Domains = unionStrings(c.PersonalDomains.Domains, other.PersonalDomains.Domains)
+	if other.sets("personal_domains.replace", other.PersonalDomains.Replace) {
+		c.PersonalDomains.Replace = other.PersonalDomains.Replace
+	}
+
+	for name, category := range other.Categories {
+		if c.Categories == nil {
+			c.Categories = make(map[string]CategoryConfig)
+		}
+		key := "categories." + name + "."
+		base := c.Categories[name]
+		base.Domains = unionStrings(base.Domains, category.Domains)
+		if other.sets(key+"replace", category.Replace) {
+			base.Replace = category.Replace
+		}
+		if other.sets(key+"disabled", category.Disabled) {
+			base.Disabled = category.Disabled
+		}
+		c.Categories[name] = base
+	}
+
+	for key := range other.explicit {
+		if c.explicit == nil {
+			c.explicit = make(map[string]bool)
+		}
+		c.explicit[key] = true
+	}
+
+	c.compiled = false
+}
+
+// sets reports whether this configuration sets a scalar setting: written in
+// one of its files, or for configurations built in code, non-zero
+func (c *Config) sets(key string, nonZero bool) bool {
+	return nonZero || c.explicit[key]
+}
+
+// explicitKeys lists the scalar settings written in a file, as
+// "vendors.<name>.<key>", "categories.<name>.<key>" and
+// "personal_domains.<key>", so that Merge can tell "priority: 0" from an
+// omitted priority
+func explicitKeys(data []byte) map[string]bool {
+	var doc struct {
+		Vendors         map[string]map[string]interface{} `yaml:"vendors"`
+		Categories      map[string]map[string]interface{} `yaml:"categories"`
+		PersonalDomains map[string]interface{}            `yaml:"personal_domains"`
+	}
+	keys := make(map[string]bool)
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return keys
+	}
+
+	for name, fields := range doc.Vendors {
+		for key := range fields {
+			keys["vendors."+name+"."+key] = true
+		}
+	}
+	for name, fields := range doc.Categories {
+		for key := range fields {
+			keys["categories."+name+"."+key] = true
+		}
+	}
+	for key := range doc.PersonalDomains {
+		keys["personal_domains."+key] = true
+	}
+	return keys
+}
+
+// uniqueIdentities drops repeated identity entries, keeping the first
+func uniqueIdentities(identities []IdentityConfig) []IdentityConfig {
+	seen := make(map[string]bool, len(identities))
+	result := make([]IdentityConfig, 0, len(identities))
+	for _, id := range identities {
+		key := fmt.Sprintf("%#v", id)
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		result = append(result, id)
+	}
+	return result
+}
+
+// unionStrings appends the entries of b missing from a (case-insensitively)
+func unionStrings(a, b []string) []string {
+	seen := make(map[string]bool, len(a)+len(b))
+	result := make([]string, 0, len(a)+len(b))
+	for _, list := range [][]string{a, b} {
+		for _, s := range list {
+			key := strings.ToLower(strings.TrimSpace(s))
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+			result = append(result, s)
+		}
+	}
+	if len(result) == 0 {
+		return nil
+	}
+	return result
+}
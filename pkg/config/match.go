@@ -0,0 +1,147 @@
+package config
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// isWildcard reports whether a domain rule uses glob syntax (e.g. "*.ibm.com")
+func isWildcard(pattern string) bool {
+	return strings.ContainsAny(pattern, "*?[")
+}
+
+// matchDomain reports whether a domain rule (exact or glob) matches domain.
+// Globs follow path.Match semantics, so "*.ibm.com" matches "us.ibm.com" and
+// "eu.corp.ibm.com" but not "ibm.com" itself.
+func matchDomain(pattern, domain string) bool {
+	pattern = strings.ToLower(strings.TrimSpace(pattern))
+	if !isWildcard(pattern) {
+		return pattern == domain
+	}
+	ok, err := path.Match(pattern, domain)
+	return err == nil && ok
+}
+
+// compileDomainPattern compiles a domain_patterns entry, anchored so that it
+// must match the whole domain
+func compileDomainPattern(pattern string) (*regexp.Regexp, error) {
+	return regexp.Compile("^(?:" + pattern + ")$")
+}
+
+// compile compiles domain patterns and identity rules, failing on the first
+// invalid entry
+func (c *Config) compile() error {
+	return c.compileRules(true)
+}
+
+// ensureCompiled compiles rules on first use for configs that were not
+// created by Load, skipping invalid entries (Load and Validate report them)
+func (c *Config) ensureCompiled() {
+	if !c.compiled {
+		_ = c.compileRules(false)
+	}
+}
+
+// compileRules compiles every vendor's domain_patterns and every identity
+func (c *Config) compileRules(strict bool) error {
+	c.patterns = make(map[string][]*regexp.Regexp, len(c.Vendors))
+	for _, name := range c.GetVendorNames() {
+		for _, p := range c.Vendors[name].DomainPatterns {
+			re, err := compileDomainPattern(p)
+			if err != nil {
+				if strict {
+					return fmt.Errorf("vendor %s: invalid domain pattern %q: %w", name, p, err)
+				}
+				continue
+			}
+			c.patterns[name] = append(c.patterns[name], re)
+		}
+	}
+
+	c.identities = make([]compiledIdentity, 0, len(c.Identities))
+	for i, id := range c.Identities {
+		compiled, err := compileIdentity(id)
+		if err != nil {
+			if strict {
+				return fmt.Errorf("identity #%d (%s): %w", i+1, id.describe(), err)
+			}
+			continue
+		}
+		c.identities = append(c.identities, compiled)
+	}
+
+	c.personal = newDomainSet(nil)
+	if !c.PersonalDomains.Replace {
+		c.personal.add(defaultPersonalDomains)
+	}
+	c.personal.add(c.PersonalDomains.Domains)
+	c.categories = c.compileCategories()
+
+	c.compiled = true
+	return nil
+}
+
+// vendorPatterns returns a vendor's compiled domain_patterns
+func (c *Config) vendorPatterns(name string) []*regexp.Regexp {
+	c.ensureCompiled()
+	return c.patterns[name]
+}
+
+// isExcluded reports whether a vendor explicitly excludes domain
+func (vc VendorConfig) isExcluded(domain string) bool {
+	for _, excluded := range vc.ExcludeDomains {
+		if matchDomain(excluded, domain) {
+			return true
+		}
+	}
+	return false
+}
+
+// domainMatches returns every vendor email rule that matches domain, in
+// precedence order: exact domains, then wildcards, then regular expressions.
+// Within each level vendors are taken in priority order; between wildcards of
+// equal priority the longest pattern wins. Vendors that exclude the domain
+// are skipped entirely.
+func (c *Config) domainMatches(domain string) []Match {
+	var exact, wildcard, patterns []Match
+
+	for _, name := range c.vendorsByPriority() {
+		vendor := c.Vendors[name]
+		if vendor.isExcluded(domain) {
+			continue
+		}
+
+		for _, d := range vendor.Domains {
+			if !matchDomain(d, domain) {
+				continue
+			}
+			if isWildcard(d) {
+				wildcard = append(wildcard, Match{Rule: RuleDomainWildcard, Category: name, Vendor: name, Pattern: d})
+			} else {
+				exact = append(exact, Match{Rule: RuleDomain, Category: name, Vendor: name, Pattern: d})
+			}
+		}
+
+		for i, re := range c.vendorPatterns(name) {
+			if re.MatchString(domain) {
+				patterns = append(patterns, Match{Rule: RuleDomainPattern, Category: name, Vendor: name, Pattern: vendor.DomainPatterns[i]})
+			}
+		}
+	}
+
+	// Priority decides first, as for every other rule; at equal priority the
+	// most specific wildcard wins ("*.eu.ibm.com" over "*.ibm.com")
+	sort.SliceStable(wildcard, func(i, j int) bool {
+		pi, pj := c.Vendors[wildcard[i].Vendor].Priority, c.Vendors[wildcard[j].Vendor].Priority
+		if pi != pj {
+			return pi > pj
+		}
+		return len(wildcard[i].Pattern) > len(wildcard[j].Pattern)
+	})
+
+	matches := append(exact, wildcard...)
+	return append(matches, patterns...)
+}
@@ -0,0 +1,46 @@
+package config
+
+// defaultPersonalDomains lists personal and consumer ISP email providers.
+// Contributors using them are counted as "community" in every mode.
+var defaultPersonalDomains = []string{
+	// Global webmail
+	"gmail.com", "googlemail.com", "yahoo.com", "ymail.com", "rocketmail.com", "hotmail.com",
+	"outlook.com", "live.com", "msn.com", "icloud.com", "me.com", "mac.com", "aol.com",
+	"aim.com", "mail.com", "email.com", "zoho.com", "zohomail.com",
+	// Regional variants of global providers
+	"yahoo.co.uk", "yahoo.co.jp", "yahoo.fr", "yahoo.de", "yahoo.es", "yahoo.it", "yahoo.ca",
+	"yahoo.com.br", "yahoo.com.ar", "yahoo.com.mx", "yahoo.co.in", "yahoo.com.au", "yahoo.com.cn",
+	"yahoo.com.tw", "yahoo.com.hk", "yahoo.co.id", "yahoo.gr", "hotmail.co.uk", "hotmail.fr",
+	"hotmail.de", "hotmail.it", "hotmail.es", "hotmail.nl", "hotmail.be", "outlook.fr",
+	"outlook.de", "outlook.es", "outlook.jp", "live.co.uk", "live.fr", "live.de", "live.nl",
+	"live.cn",
+	// Privacy-focused providers
+	"protonmail.com", "protonmail.ch", "proton.me", "pm.me", "tutanota.com", "tutanota.de",
+	"tuta.io", "tuta.com", "fastmail.com", "fastmail.fm", "hey.com", "duck.com", "hushmail.com",
+	"riseup.net", "disroot.org", "runbox.com", "mailfence.com", "startmail.com", "kolabnow.com",
+	"mailbox.org", "posteo.de", "posteo.net",
+	// Germany, Austria, Switzerland
+	"gmx.com", "gmx.net", "gmx.de", "gmx.at", "gmx.ch", "gmx.fr", "web.de", "t-online.de",
+	"freenet.de", "mail.de", "email.de", "arcor.de", "online.de", "vodafone.de", "bluewin.ch",
+	"hispeed.ch", "chello.at", "aon.at",
+	// France, Italy, Benelux
+	"free.fr", "orange.fr", "wanadoo.fr", "laposte.net", "sfr.fr", "neuf.fr", "bbox.fr",
+	"libero.it", "virgilio.it", "tiscali.it", "alice.it", "tin.it", "kpnmail.nl", "ziggo.nl",
+	"planet.nl", "hetnet.nl", "home.nl", "telenet.be", "skynet.be",
+	// Central and Eastern Europe
+	"yandex.com", "yandex.ru", "ya.ru", "mail.ru", "inbox.ru", "list.ru", "bk.ru", "rambler.ru",
+	"ukr.net", "i.ua", "meta.ua", "tut.by", "inbox.lv", "abv.bg", "seznam.cz", "centrum.cz",
+	"wp.pl", "o2.pl", "onet.pl", "interia.pl", "op.pl",
+	// China, Korea, India
+	"qq.com", "vip.qq.com", "foxmail.com", "163.com", "126.com", "yeah.net", "139.com",
+	"189.cn", "sina.com", "sina.cn", "sohu.com", "aliyun.com", "tom.com", "21cn.com",
+	"naver.com", "hanmail.net", "daum.net", "kakao.com", "nate.com", "rediffmail.com",
+	// UK, North America, Oceania, Brazil ISPs
+	"btinternet.com", "sky.com", "virginmedia.com", "ntlworld.com", "talktalk.net", "comcast.net",
+	"verizon.net", "att.net", "sbcglobal.net", "cox.net", "charter.net", "earthlink.net",
+	"bellsouth.net", "optonline.net", "shaw.ca", "rogers.com", "sympatico.ca", "telus.net",
+	"bigpond.com", "optusnet.com.au", "xtra.co.nz", "uol.com.br", "bol.com.br", "terra.com.br",
+	"ig.com.br",
+	// Forge privacy addresses
+	"users.noreply.github.com", "users.noreply.gitlab.com",
+}
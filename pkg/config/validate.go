@@ -0,0 +1,580 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"path"
+	"regexp"
+	"regexp/syntax"
+	"sort"
+	"strings"
+
+	"github.com/sderosiaux/git-contributor-insights/pkg/palette"
+	"gopkg.in/yaml.v3"
+)
+
+// Issue severities reported by Validate
+const (
+	SeverityError   = "error"
+	SeverityWarning = "warning"
+	SeverityInfo    = "info"
+)
+
+// OthersVendor is reserved for the bucket --top and --min-share fold the
+// long tail of vendors into
+const OthersVendor = "others"
+
+// Issue describes a problem found while validating a configuration
+type Issue struct {
+	Severity string
+	Vendor   string // vendor the issue relates to, if any
+	Message  string
+}
+
+// domainPattern matches syntactically valid, lowercase domain names
+var domainPattern = regexp.MustCompile(`^[a-z0-9]([a-z0-9-]*[a-z0-9])?(\.[a-z0-9]([a-z0-9-]*[a-z0-9])?)+$`)
+
+// ValidateFile checks a configuration file for unknown keys, overlapping
+// rules, empty vendors and malformed domains. The returned error is only
+// set when the file cannot be read or parsed at all.
+func ValidateFile(path string) ([]Issue, error) {
+	cfg, files, err := loadTree(path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	issues := make([]Issue, 0)
+	for _, f := range files {
+		prefix := ""
+		if len(files) > 1 {
+			prefix = f.path + ": "
+		}
+		issues = append(issues, checkUnknownKeys(f.data, prefix)...)
+	}
+	issues = append(issues, cfg.Validate()...)
+	return issues, nil
+}
+
+// checkUnknownKeys decodes strictly to report keys that Load silently ignores
+func checkUnknownKeys(data []byte, prefix string) []Issue {
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+
+	var strict Config
+	err := decoder.Decode(&strict)
+
+	var typeErr *yaml.TypeError
+	if !errors.As(err, &typeErr) {
+		return nil
+	}
+
+	issues := make([]Issue, 0, len(typeErr.Errors))
+	for _, msg := range typeErr.Errors {
+		issues = append(issues, Issue{Severity: SeverityError, Message: prefix + "unknown key: " + msg})
+	}
+	return issues
+}
+
+// Validate checks the loaded configuration for empty vendors, malformed
+// domains and rules that would match in several vendors
+func (c *Config) Validate() []Issue {
+	issues := make([]Issue, 0)
+
+	issues = append(issues, c.validateIdentities()...)
+
+	for _, d := range c.PersonalDomains.Domains {
+		if issue, ok := checkDomain("", "personal domain", d); ok {
+			issues = append(issues, issue)
+		}
+	}
+
+	issues = append(issues, c.validateCategories()...)
+
+	if len(c.Vendors) == 0 {
+		issues = append(issues, Issue{
+			Severity: SeverityWarning,
+			Message:  "no vendors configured; automatic domain classification will be used",
+		})
+		return issues
+	}
+
+	names := c.GetVendorNames()
+
+	c.ensureCompiled()
+	for _, name := range names {
+		issues = append(issues, validateVendor(name, c.Vendors[name], c.personal)...)
+	}
+
+	issues = append(issues, c.checkParents(names)...)
+	issues = append(issues, c.checkColors(names)...)
+	issues = append(issues, c.checkDomainOverlaps(names)...)
+	issues = append(issues, c.checkBroadOverlaps(names)...)
+	issues = append(issues, c.checkCompanyOverlaps(names)...)
+
+	return issues
+}
+
+// validateIdentities checks identity overrides for invalid fields and
+// vendors that are not configured
+func (c *Config) validateIdentities() []Issue {
+	issues := make([]Issue, 0)
+	for i, id := range c.Identities {
+		if _, err := compileIdentity(id); err != nil {
+			issues = append(issues, Issue{SeverityError, id.Vendor, fmt.Sprintf("identity #%d (%s): %v", i+1, id.describe(), err)})
+			continue
+		}
+		if _, ok := c.Vendors[id.Vendor]; !ok && id.Vendor != "community" {
+			issues = append(issues, Issue{SeverityWarning, id.Vendor, fmt.Sprintf("identity #%d (%s) uses a vendor with no other rules; it will appear as its own category", i+1, id.describe())})
+		}
+	}
+	return issues
+}
+
+// validateCategories checks category domains and names that clash with vendors
+func (c *Config) validateCategories() []Issue {
+	issues := make([]Issue, 0)
+
+	names := make([]string, 0, len(c.Categories))
+	for name := range c.Categories {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		category := c.Categories[name]
+		for _, d := range category.Domains {
+			if issue, ok := checkDomain("", "category "+name+" domain", d); ok {
+				issues = append(issues, issue)
+			}
+		}
+
+		switch _, vendor := c.Vendors[name]; {
+		case name == "community":
+			issues = append(issues, Issue{SeverityError, "", `category "community" is reserved; use personal_domains instead`})
+		case name == OthersVendor:
+			issues = append(issues, Issue{SeverityError, "", `category "others" is reserved for the vendors grouped by --top and --min-share`})
+		case vendor:
+			issues = append(issues, Issue{SeverityWarning, name, fmt.Sprintf("category %q has the same name as a vendor; both are reported together", name)})
+		case category.Replace && len(category.Domains) == 0 && !category.Disabled:
+			issues = append(issues, Issue{SeverityWarning, "", fmt.Sprintf("category %q replaces its built-in domains with none and will never match", name)})
+		}
+	}
+	return issues
+}
+
+// validateVendor checks a single vendor's rules
+func validateVendor(name string, vendor VendorConfig, personal *domainSet) []Issue {
+	issues := make([]Issue, 0)
+
+	if name == OthersVendor {
+		issues = append(issues, Issue{SeverityError, name, `vendor name "others" is reserved for the vendors grouped by --top and --min-share`})
+	}
+
+	if len(vendor.Domains) == 0 && len(vendor.DomainPatterns) == 0 && len(vendor.GithubCompanies) == 0 {
+		issues = append(issues, Issue{
+			Severity: SeverityWarning,
+			Vendor:   name,
+			Message:  "vendor has no domains, domain_patterns or github_companies and will never match",
+		})
+	}
+
+	seen := make(map[string]bool)
+	for _, d := range vendor.Domains {
+		lower := strings.ToLower(strings.TrimSpace(d))
+		if issue, ok := checkDomain(name, "domain", d); ok {
+			issues = append(issues, issue)
+		}
+		if !isWildcard(lower) && personal.contains(lower) {
+			issues = append(issues, Issue{SeverityWarning, name, fmt.Sprintf("domain %q is a personal email provider and will never match; use identities instead", d)})
+		}
+
+		if seen[lower] {
+			issues = append(issues, Issue{SeverityWarning, name, fmt.Sprintf("domain %q is listed more than once", d)})
+		}
+		seen[lower] = true
+	}
+
+	for _, d := range vendor.ExcludeDomains {
+		if issue, ok := checkDomain(name, "excluded domain", d); ok {
+			issues = append(issues, issue)
+		}
+	}
+
+	for _, p := range vendor.DomainPatterns {
+		if _, err := compileDomainPattern(p); err != nil {
+			issues = append(issues, Issue{SeverityError, name, fmt.Sprintf("domain pattern %q is not a valid regular expression: %v", p, err)})
+		}
+	}
+
+	seen = make(map[string]bool)
+	for _, company := range vendor.GithubCompanies {
+		lower := strings.ToLower(strings.TrimSpace(company))
+		if lower == "" {
+			issues = append(issues, Issue{SeverityError, name, "empty github_companies entry matches every company"})
+			continue
+		}
+		if seen[lower] {
+			issues = append(issues, Issue{SeverityWarning, name, fmt.Sprintf("company %q is listed more than once", company)})
+		}
+		seen[lower] = true
+	}
+
+	if vendor.Color != "" && !palette.IsHex(vendor.Color) {
+		issues = append(issues, Issue{SeverityError, name, fmt.Sprintf("color %q is not a hex color such as \"#e4572e\"", vendor.Color)})
+	}
+
+	return issues
+}
+
+// checkColors reports pinned colors shared by several vendors
+func (c *Config) checkColors(names []string) []Issue {
+	issues := make([]Issue, 0)
+	owners := make(map[string]string)
+	for _, name := range names {
+		color := strings.ToLower(c.Vendors[name].Color)
+		if !palette.IsHex(color) {
+			continue
+		}
+		if owner, ok := owners[color]; ok {
+			issues = append(issues, Issue{SeverityWarning, name, fmt.Sprintf("color %q is also pinned by vendor %q", color, owner)})
+			continue
+		}
+		owners[color] = name
+	}
+	return issues
+}
+
+// checkDomain validates a single domain or domain glob
+func checkDomain(vendor, kind, d string) (Issue, bool) {
+	lower := strings.ToLower(strings.TrimSpace(d))
+
+	// Validate globs by substituting a label for each wildcard
+	candidate := lower
+	if isWildcard(lower) {
+		if _, err := path.Match(lower, ""); err != nil {
+			return Issue{SeverityError, vendor, fmt.Sprintf("%s %q is not a valid glob: %v", kind, d, err)}, true
+		}
+		candidate = strings.NewReplacer("*", "x", "?", "x").Replace(lower)
+	}
+
+	switch {
+	case strings.Contains(d, "@"):
+		return Issue{SeverityError, vendor, fmt.Sprintf("%s %q contains '@'; list the domain only", kind, d)}, true
+	case strings.Contains(d, "://"):
+		return Issue{SeverityError, vendor, fmt.Sprintf("%s %q is a URL; list the domain only", kind, d)}, true
+	case !strings.Contains(candidate, "[") && !domainPattern.MatchString(candidate):
+		return Issue{SeverityError, vendor, fmt.Sprintf("%s %q is not a valid domain name", kind, d)}, true
+	case d != lower:
+		return Issue{SeverityInfo, vendor, fmt.Sprintf("%s %q is matched case-insensitively; prefer %q", kind, d, lower)}, true
+	}
+	return Issue{}, false
+}
+
+// checkParents reports parent links that point to the vendor itself or form a cycle
+func (c *Config) checkParents(names []string) []Issue {
+	issues := make([]Issue, 0)
+	for _, name := range names {
+		parent := c.Vendors[name].Parent
+		switch {
+		case parent == "":
+			continue
+		case parent == name:
+			issues = append(issues, Issue{SeverityError, name, "vendor is its own parent"})
+			continue
+		case parent == "community":
+			issues = append(issues, Issue{SeverityError, name, `parent "community" is reserved`})
+			continue
+		}
+
+		seen := map[string]bool{name: true}
+		for current := parent; current != ""; current = c.Vendors[current].Parent {
+			if seen[current] {
+				issues = append(issues, Issue{SeverityError, name, fmt.Sprintf("parent chain through %q forms a cycle", parent)})
+				break
+			}
+			seen[current] = true
+		}
+	}
+	return issues
+}
+
+// checkDomainOverlaps reports domains claimed by more than one vendor, both
+// as exact entries and through another vendor's wildcard or domain pattern
+func (c *Config) checkDomainOverlaps(names []string) []Issue {
+	owners := make(map[string][]string)
+	for _, name := range names {
+		for _, d := range c.Vendors[name].Domains {
+			lower := strings.ToLower(strings.TrimSpace(d))
+			if !containsString(owners[lower], name) {
+				owners[lower] = append(owners[lower], name)
+			}
+		}
+	}
+
+	domains := make([]string, 0, len(owners))
+	for d, vendors := range owners {
+		if len(vendors) > 1 {
+			domains = append(domains, d)
+		}
+	}
+	sort.Strings(domains)
+
+	issues := make([]Issue, 0)
+	for _, d := range domains {
+		issues = append(issues, c.overlapIssue(fmt.Sprintf("domain %q", d), owners[d]))
+	}
+
+	// Exact domains win over wildcards and patterns, but a broad rule that
+	// captures another vendor's domain usually captures more than intended
+	for _, name := range names {
+		for _, d := range c.Vendors[name].Domains {
+			lower := strings.ToLower(strings.TrimSpace(d))
+			if isWildcard(lower) {
+				continue
+			}
+			for _, m := range c.domainMatches(lower) {
+				if m.Vendor == name || m.Rule == RuleDomain {
+					continue
+				}
+				kind := "wildcard"
+				if m.Rule == RuleDomainPattern {
+					kind = "domain pattern"
+				}
+				issues = append(issues, Issue{SeverityWarning, m.Vendor, fmt.Sprintf("%s %q also matches domain %q of vendor %s (the exact domain wins); narrow the rule or add it to exclude_domains",
+					kind, m.Pattern, d, name)})
+			}
+		}
+	}
+	return issues
+}
+
+// checkBroadOverlaps reports wildcards and domain patterns of different
+// vendors that match the same domains. Such overlaps are decided silently
+// by precedence, so each issue names the rule that wins.
+func (c *Config) checkBroadOverlaps(names []string) []Issue {
+	issues := make([]Issue, 0)
+	seen := make(map[string]bool)
+	for _, name := range names {
+		vendor := c.Vendors[name]
+		samples := make([]string, 0, len(vendor.Domains)+len(vendor.DomainPatterns))
+		for _, d := range vendor.Domains {
+			if sample, ok := sampleWildcard(strings.ToLower(strings.TrimSpace(d))); ok {
+				samples = append(samples, sample)
+			}
+		}
+		for _, p := range vendor.DomainPatterns {
+			if sample, ok := samplePattern(p); ok {
+				samples = append(samples, sample)
+			}
+		}
+
+		for _, sample := range samples {
+			broad := make([]Match, 0)
+			for _, m := range c.domainMatches(sample) {
+				if m.Rule != RuleDomain {
+					broad = append(broad, m)
+				}
+			}
+			for i, a := range broad {
+				for _, b := range broad[i+1:] {
+					if a.Vendor == b.Vendor || (a.Vendor != name && b.Vendor != name) {
+						continue
+					}
+					// Identical wildcards are reported with the duplicate domains
+					if a.Rule == b.Rule && a.Rule == RuleDomainWildcard && strings.EqualFold(a.Pattern, b.Pattern) {
+						continue
+					}
+					key := a.Vendor + "\x00" + a.Pattern + "\x00" + b.Vendor + "\x00" + b.Pattern
+					if seen[key] {
+						continue
+					}
+					seen[key] = true
+					issues = append(issues, c.broadOverlapIssue(a, b, sample))
+				}
+			}
+		}
+	}
+	return issues
+}
+
+// broadOverlapIssue describes two broad rules matching sample, where a
+// takes precedence over b
+func (c *Config) broadOverlapIssue(a, b Match, sample string) Issue {
+	rule := fmt.Sprintf("%s %q and %s %q (e.g. %q)", ruleKind(a), a.Pattern, ruleKind(b), b.Pattern, sample)
+	pa, pb := c.Vendors[a.Vendor].Priority, c.Vendors[b.Vendor].Priority
+
+	var reason string
+	switch {
+	case a.Rule != b.Rule:
+		reason = "wildcards take precedence over domain patterns"
+	case pa != pb:
+		return c.overlapIssue(rule, []string{a.Vendor, b.Vendor})
+	case a.Rule == RuleDomainWildcard && len(a.Pattern) != len(b.Pattern):
+		reason = "the longer wildcard is more specific"
+	default:
+		return c.overlapIssue(rule, []string{a.Vendor, b.Vendor})
+	}
+	return Issue{SeverityWarning, a.Vendor, fmt.Sprintf("%s claimed by vendors %s, %s; %s wins as %s; narrow the rules or add exclude_domains",
+		rule, a.Vendor, b.Vendor, a.Vendor, reason)}
+}
+
+// ruleKind names a broad domain rule in messages
+func ruleKind(m Match) string {
+	if m.Rule == RuleDomainPattern {
+		return "domain pattern"
+	}
+	return "wildcard"
+}
+
+// sampleWildcard returns a domain matched by a wildcard domain entry, or
+// false for exact domains and negated character classes
+func sampleWildcard(pattern string) (string, bool) {
+	if !isWildcard(pattern) {
+		return "", false
+	}
+	var b strings.Builder
+	for i := 0; i < len(pattern); i++ {
+		switch pattern[i] {
+		case '*', '?':
+			b.WriteByte('x')
+		case '[':
+			end := strings.IndexByte(pattern[i:], ']')
+			if end < 2 || pattern[i+1] == '^' {
+				return "", false
+			}
+			b.WriteByte(pattern[i+1])
+			i += end
+		case '\\':
+			if i+1 < len(pattern) {
+				i++
+				b.WriteByte(pattern[i])
+			}
+		default:
+			b.WriteByte(pattern[i])
+		}
+	}
+	return b.String(), true
+}
+
+// samplePattern returns a short domain matched by a domain_patterns entry,
+// built from the first alternative and minimum repetitions of each part
+func samplePattern(pattern string) (string, bool) {
+	re, err := syntax.Parse(pattern, syntax.Perl)
+	if err != nil {
+		return "", false
+	}
+	var b strings.Builder
+	if !writeSample(&b, re.Simplify()) {
+		return "", false
+	}
+	return strings.ToLower(b.String()), true
+}
+
+// writeSample appends a string matched by re to b
+func writeSample(b *strings.Builder, re *syntax.Regexp) bool {
+	switch re.Op {
+	case syntax.OpLiteral:
+		b.WriteString(string(re.Rune))
+	case syntax.OpCharClass:
+		if len(re.Rune) == 0 {
+			return false
+		}
+		b.WriteRune(sampleRune(re.Rune))
+	case syntax.OpAnyChar, syntax.OpAnyCharNotNL:
+		b.WriteByte('x')
+	case syntax.OpCapture, syntax.OpPlus:
+		return writeSample(b, re.Sub[0])
+	case syntax.OpAlternate:
+		return writeSample(b, re.Sub[0])
+	case syntax.OpRepeat:
+		for i := 0; i < re.Min; i++ {
+			if !writeSample(b, re.Sub[0]) {
+				return false
+			}
+		}
+	case syntax.OpConcat:
+		for _, sub := range re.Sub {
+			if !writeSample(b, sub) {
+				return false
+			}
+		}
+	case syntax.OpStar, syntax.OpQuest, syntax.OpEmptyMatch,
+		syntax.OpBeginLine, syntax.OpEndLine, syntax.OpBeginText, syntax.OpEndText:
+	default:
+		return false
+	}
+	return true
+}
+
+// sampleRune picks a readable rune from character class ranges, preferring
+// "x" or a digit over punctuation
+func sampleRune(ranges []rune) rune {
+	for _, want := range []rune{'x', '0'} {
+		for i := 0; i+1 < len(ranges); i += 2 {
+			if ranges[i] <= want && want <= ranges[i+1] {
+				return want
+			}
+		}
+	}
+	return ranges[0]
+}
+
+// checkCompanyOverlaps reports company substrings that also match another
+// vendor's company (e.g. "IBM" also matches "IBM Red Hat")
+func (c *Config) checkCompanyOverlaps(names []string) []Issue {
+	issues := make([]Issue, 0)
+	for i, a := range names {
+		for _, b := range names[i+1:] {
+			for _, ca := range c.Vendors[a].GithubCompanies {
+				for _, cb := range c.Vendors[b].GithubCompanies {
+					la := strings.ToLower(strings.TrimSpace(ca))
+					lb := strings.ToLower(strings.TrimSpace(cb))
+					if la == "" || lb == "" {
+						continue
+					}
+					if strings.Contains(la, lb) || strings.Contains(lb, la) {
+						issues = append(issues, c.overlapIssue(fmt.Sprintf("companies %q and %q", ca, cb), []string{a, b}))
+					}
+				}
+			}
+		}
+	}
+	return issues
+}
+
+// overlapIssue reports a rule shared by several vendors. Overlaps are
+// errors unless an explicit priority decides the winner.
+func (c *Config) overlapIssue(rule string, vendors []string) Issue {
+	ordered := make([]string, len(vendors))
+	copy(ordered, vendors)
+	sort.SliceStable(ordered, func(i, j int) bool {
+		return c.Vendors[ordered[i]].Priority > c.Vendors[ordered[j]].Priority
+	})
+
+	top := c.Vendors[ordered[0]].Priority
+	if c.Vendors[ordered[1]].Priority < top {
+		return Issue{
+			Severity: SeverityInfo,
+			Vendor:   ordered[0],
+			Message:  fmt.Sprintf("%s claimed by vendors %s; %s wins on priority %d", rule, strings.Join(ordered, ", "), ordered[0], top),
+		}
+	}
+
+	return Issue{
+		Severity: SeverityError,
+		Vendor:   ordered[0],
+		Message: fmt.Sprintf("%s claimed by vendors %s with equal priority; set 'priority' on the vendor that should win",
+			rule, strings.Join(ordered, ", ")),
+	}
+}
+
+// containsString reports whether list contains s
+func containsString(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
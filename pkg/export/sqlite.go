@@ -0,0 +1,235 @@
+package export
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	_ "modernc.org/sqlite" // registers the "sqlite" driver
+
+	"github.com/sderosiaux/git-contributor-insights/pkg/analyzer"
+	"github.com/sderosiaux/git-contributor-insights/pkg/config"
+	"github.com/sderosiaux/git-contributor-insights/pkg/types"
+)
+
+// Breakdowns are the period granularities assigned to every commit
+var Breakdowns = []string{"year", "quarter", "month", "week"}
+
+// schema creates the normalized tables. Dates are stored as UTC ISO-8601
+// text so SQLite date functions (strftime, date) work on them directly.
+const schema = `
+CREATE TABLE export_info (
+	key   TEXT PRIMARY KEY,
+	value TEXT NOT NULL
+);
+
+CREATE TABLE identities (
+	id     INTEGER PRIMARY KEY,
+	key    TEXT NOT NULL UNIQUE, -- email, or name when the email is missing
+	name   TEXT NOT NULL,
+	email  TEXT NOT NULL,
+	domain TEXT NOT NULL
+);
+
+CREATE TABLE commits (
+	sha            TEXT PRIMARY KEY,
+	identity_id    INTEGER NOT NULL REFERENCES identities(id),
+	author_name    TEXT NOT NULL,
+	author_email   TEXT NOT NULL,
+	author_company TEXT,
+	authored_at    TEXT NOT NULL,
+	additions      INTEGER NOT NULL,
+	deletions      INTEGER NOT NULL,
+	message        TEXT NOT NULL
+);
+
+CREATE TABLE file_changes (
+	commit_sha TEXT NOT NULL REFERENCES commits(sha),
+	path       TEXT NOT NULL,
+	additions  INTEGER NOT NULL,
+	deletions  INTEGER NOT NULL,
+	PRIMARY KEY (commit_sha, path)
+);
+
+CREATE TABLE classifications (
+	commit_sha TEXT PRIMARY KEY REFERENCES commits(sha),
+	category   TEXT NOT NULL, -- vendor, community, academia, @domain, ...
+	parent     TEXT NOT NULL, -- top-level company (same as category without a parent)
+	rule       TEXT NOT NULL, -- rule that decided the category (see ghca explain)
+	vendor     TEXT,          -- configured vendor owning the rule
+	pattern    TEXT           -- configured value that matched
+);
+
+CREATE TABLE commit_periods (
+	commit_sha  TEXT NOT NULL REFERENCES commits(sha),
+	granularity TEXT NOT NULL, -- year, quarter, month, week
+	period      TEXT NOT NULL, -- 2024, 2024-Q3, 2024-07, 2024-W28
+	PRIMARY KEY (commit_sha, granularity)
+);
+
+CREATE INDEX idx_commits_identity ON commits(identity_id);
+CREATE INDEX idx_commits_authored_at ON commits(authored_at);
+CREATE INDEX idx_identities_domain ON identities(domain);
+CREATE INDEX idx_file_changes_path ON file_changes(path);
+CREATE INDEX idx_classifications_category ON classifications(category);
+CREATE INDEX idx_classifications_parent ON classifications(parent);
+CREATE INDEX idx_commit_periods_period ON commit_periods(granularity, period);
+`
+
+// Dataset is the data written by WriteSQLite
+type Dataset struct {
+	RepoName    string
+	Commits     []*types.CommitData
+	Config      *config.Config
+	ConfigFiles []string
+	GeneratedAt time.Time
+}
+
+// WriteSQLite writes the commits, their files, identities, classifications
+// and period assignments into a new SQLite database. The database is built
+// next to path and only replaces an existing file once it is complete.
+func WriteSQLite(path string, data *Dataset) error {
+	tmp := path + ".tmp"
+	if err := os.Remove(tmp); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("failed to remove stale %s: %w", tmp, err)
+	}
+
+	if err := writeDatabase(tmp, data); err != nil {
+		os.Remove(tmp)
+		return err
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		os.Remove(tmp)
+		return fmt.Errorf("failed to replace %s: %w", path, err)
+	}
+	return nil
+}
+
+// writeDatabase creates the schema and rows in a new database file
+func writeDatabase(path string, data *Dataset) error {
+	db, err := sql.Open("sqlite", path)
+	if err != nil {
+		return fmt.Errorf("failed to open database: %w", err)
+	}
+	defer db.Close()
+
+	if _, err := db.Exec(schema); err != nil {
+		return fmt.Errorf("failed to create schema: %w", err)
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	if err := writeRows(tx, data); err != nil {
+		tx.Rollback()
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit: %w", err)
+	}
+
+	return db.Close()
+}
+
+// writeRows inserts every row of the dataset within a transaction
+func writeRows(tx *sql.Tx, data *Dataset) error {
+	insert := func(query string) (*sql.Stmt, error) {
+		stmt, err := tx.Prepare(query)
+		if err != nil {
+			return nil, fmt.Errorf("failed to prepare %q: %w", query, err)
+		}
+		return stmt, nil
+	}
+
+	info := map[string]string{
+		"repo":         data.RepoName,
+		"generated_at": data.GeneratedAt.UTC().Format(time.RFC3339),
+		"commits":      fmt.Sprintf("%d", len(data.Commits)),
+		"config_files": strings.Join(data.ConfigFiles, ","),
+	}
+	for key, value := range info {
+		if _, err := tx.Exec(`INSERT INTO export_info (key, value) VALUES (?, ?)`, key, value); err != nil {
+			return fmt.Errorf("failed to write export info: %w", err)
+		}
+	}
+
+	identityStmt, err := insert(`INSERT INTO identities (id, key, name, email, domain) VALUES (?, ?, ?, ?, ?)`)
+	if err != nil {
+		return err
+	}
+	commitStmt, err := insert(`INSERT OR IGNORE INTO commits (sha, identity_id, author_name, author_email, author_company, authored_at, additions, deletions, message) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`)
+	if err != nil {
+		return err
+	}
+	fileStmt, err := insert(`INSERT OR IGNORE INTO file_changes (commit_sha, path, additions, deletions) VALUES (?, ?, ?, ?)`)
+	if err != nil {
+		return err
+	}
+	classStmt, err := insert(`INSERT OR IGNORE INTO classifications (commit_sha, category, parent, rule, vendor, pattern) VALUES (?, ?, ?, ?, ?, ?)`)
+	if err != nil {
+		return err
+	}
+	periodStmt, err := insert(`INSERT OR IGNORE INTO commit_periods (commit_sha, granularity, period) VALUES (?, ?, ?)`)
+	if err != nil {
+		return err
+	}
+
+	identities := make(map[string]int64)
+	for _, commit := range data.Commits {
+		key := commit.ContributorID()
+		id, ok := identities[key]
+		if !ok {
+			id = int64(len(identities) + 1)
+			identities[key] = id
+			if _, err := identityStmt.Exec(id, key, commit.AuthorName, commit.AuthorEmail, config.EmailDomain(commit.AuthorEmail)); err != nil {
+				return fmt.Errorf("failed to write identity %s: %w", key, err)
+			}
+		}
+
+		if _, err := commitStmt.Exec(commit.SHA, id, commit.AuthorName, commit.AuthorEmail, nullable(commit.AuthorCompany),
+			commit.Date.UTC().Format(time.RFC3339), commit.Additions, commit.Deletions, commit.Message); err != nil {
+			return fmt.Errorf("failed to write commit %s: %w", commit.SHA, err)
+		}
+
+		for _, file := range commit.Files {
+			if _, err := fileStmt.Exec(commit.SHA, file.Path, file.Additions, file.Deletions); err != nil {
+				return fmt.Errorf("failed to write file changes of %s: %w", commit.SHA, err)
+			}
+		}
+
+		exp := data.Config.Explain(config.Contributor{
+			Name:    commit.AuthorName,
+			Email:   commit.AuthorEmail,
+			Company: commit.AuthorCompany,
+			Date:    commit.Date,
+		})
+		parent := exp.Category
+		if exp.Parent != "" {
+			parent = exp.Parent
+		}
+		if _, err := classStmt.Exec(commit.SHA, exp.Category, parent, exp.Matched.Rule,
+			nullable(exp.Matched.Vendor), nullable(exp.Matched.Pattern)); err != nil {
+			return fmt.Errorf("failed to write classification of %s: %w", commit.SHA, err)
+		}
+
+		for _, granularity := range Breakdowns {
+			if _, err := periodStmt.Exec(commit.SHA, granularity, analyzer.PeriodKey(commit.Date, granularity)); err != nil {
+				return fmt.Errorf("failed to write periods of %s: %w", commit.SHA, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// nullable stores empty strings as NULL
+func nullable(s string) interface{} {
+	if s == "" {
+		return nil
+	}
+	return s
+}
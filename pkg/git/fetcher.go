@@ -15,6 +15,10 @@ import (
 type Fetcher struct {
 	repo *git.Repository
 	path string
+
+	// FileStats keeps per-file line counts on every commit (CommitData.Files).
+	// Off by default: only the SQLite export needs them.
+	FileStats bool
 }
 
 // NewFetcher creates a new Git fetcher
@@ -167,9 +171,20 @@ func (f *Fetcher) processCommit(commit *object.Commit) (*types.CommitData, error
 
 	additions := 0
 	deletions := 0
+	var files []types.FileStat
+	if f.FileStats {
+		files = make([]types.FileStat, 0, len(stats))
+	}
 	for _, stat := range stats {
 		additions += stat.Addition
 		deletions += stat.Deletion
+		if f.FileStats {
+			files = append(files, types.FileStat{
+				Path:      stat.Name,
+				Additions: stat.Addition,
+				Deletions: stat.Deletion,
+			})
+		}
 	}
 
 	// Get first line of message
@@ -186,6 +201,7 @@ func (f *Fetcher) processCommit(commit *object.Commit) (*types.CommitData, error
 		Additions:   additions,
 		Deletions:   deletions,
 		Message:     message,
+		Files:       files,
 	}, nil
 }
 
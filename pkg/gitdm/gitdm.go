@@ -0,0 +1,324 @@
+package gitdm
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/sderosiaux/git-contributor-insights/pkg/config"
+)
+
+// communityCompanies are affiliations that mean "no employer"
+var communityCompanies = map[string]bool{
+	"independent": true, "self": true, "self-employed": true, "unemployed": true,
+	"freelance": true, "freelancer": true, "hobbyist": true,
+}
+
+// unknownCompanies are placeholder affiliations that carry no information
+var unknownCompanies = map[string]bool{
+	"notfound": true, "(unknown)": true, "unknown": true, "?": true, "(robots)": true, "-": true,
+}
+
+// legalSuffixes are stripped from company names when deriving vendor keys
+var legalSuffixes = []string{
+	"incorporated", "inc", "llc", "ltd", "limited", "corp", "corporation",
+	"co", "gmbh", "ag", "sa", "s.a", "plc", "bv", "b.v", "srl", "oy", "ab",
+}
+
+var (
+	untilPattern   = regexp.MustCompile(`^(.*?)\s+until\s+(\d{4}-\d{2}-\d{2})$`)
+	boundedPattern = regexp.MustCompile(`^(.*?)\s*<\s*(\d{4}-\d{2}-\d{2})$`)
+	slugPattern    = regexp.MustCompile(`[^a-z0-9]+`)
+)
+
+// affiliation is one employer line of a developer, in file order
+type affiliation struct {
+	company string
+	until   string // exclusive end date (YYYY-MM-DD), empty for the current employer
+}
+
+// developer is a developers_affiliations.txt entry
+type developer struct {
+	key          string // GitHub login or name heading the entry
+	emails       []string
+	affiliations []affiliation
+}
+
+// Importer converts gitdm affiliation files into a vendor configuration.
+// Files can be read in any number and order; later entries for the same
+// developer or domain replace earlier ones.
+type Importer struct {
+	developers map[string]*developer
+	domains    map[string]string // domain -> company
+	companies  map[string]string // vendor key -> company name as first seen
+	Warnings   []string
+}
+
+// NewImporter creates an empty Importer
+func NewImporter() *Importer {
+	return &Importer{
+		developers: make(map[string]*developer),
+		domains:    make(map[string]string),
+		companies:  make(map[string]string),
+	}
+}
+
+// ReadAffiliationsFile reads a developers_affiliations.txt file
+func (im *Importer) ReadAffiliationsFile(path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	return im.ReadAffiliations(f, path)
+}
+
+// ReadDomainMapFile reads a gitdm domain-map file
+func (im *Importer) ReadDomainMapFile(path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	return im.ReadDomainMap(f, path)
+}
+
+// ReadAffiliations parses developers_affiliations.txt content:
+//
+//	login: first!example.com, other!example.org
+//		Company A until 2018-03-01
+//		Company B
+//
+// Emails use "!" in place of "@". Indented lines list employers in
+// chronological order; "until" ends an affiliation the day before the date.
+func (im *Importer) ReadAffiliations(r io.Reader, source string) error {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+
+	var current *developer
+	lineNo := 0
+	for scanner.Scan() {
+		lineNo++
+		raw := scanner.Text()
+		line := strings.TrimSpace(raw)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		// Indented lines are employers of the current developer
+		if raw[0] == ' ' || raw[0] == '\t' {
+			if current == nil {
+				im.warnf("%s:%d: affiliation without a developer", source, lineNo)
+				continue
+			}
+			aff := affiliation{company: line}
+			if m := untilPattern.FindStringSubmatch(line); m != nil {
+				aff = affiliation{company: strings.TrimSpace(m[1]), until: m[2]}
+			}
+			current.affiliations = append(current.affiliations, aff)
+			continue
+		}
+
+		idx := strings.Index(line, ":")
+		if idx <= 0 {
+			im.warnf("%s:%d: expected \"login: emails\"", source, lineNo)
+			current = nil
+			continue
+		}
+
+		current = &developer{key: strings.TrimSpace(line[:idx])}
+		for _, email := range strings.Split(line[idx+1:], ",") {
+			email = strings.ToLower(strings.TrimSpace(strings.ReplaceAll(email, "!", "@")))
+			if strings.Contains(email, "@") {
+				current.emails = append(current.emails, email)
+			}
+		}
+
+		if _, exists := im.developers[current.key]; exists {
+			im.warnf("%s:%d: %s listed again, replacing the earlier entry", source, lineNo, current.key)
+		}
+		im.developers[current.key] = current
+	}
+
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("reading %s: %w", source, err)
+	}
+	return nil
+}
+
+// ReadDomainMap parses gitdm domain-map content ("domain Company Name",
+// optionally followed by "< YYYY-MM-DD"). Vendor domains cannot be
+// time-bounded, so bounded entries are skipped in favor of the current owner.
+func (im *Importer) ReadDomainMap(r io.Reader, source string) error {
+	scanner := bufio.NewScanner(r)
+
+	lineNo := 0
+	for scanner.Scan() {
+		lineNo++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		fields := strings.Fields(line)
+		if len(fields) < 2 {
+			im.warnf("%s:%d: expected \"domain company\"", source, lineNo)
+			continue
+		}
+
+		domain := strings.ToLower(fields[0])
+		company := strings.Join(fields[1:], " ")
+		if m := boundedPattern.FindStringSubmatch(company); m != nil {
+			im.warnf("%s:%d: %s is mapped to %s only until %s; time-bounded domains are not supported, skipped",
+				source, lineNo, domain, strings.TrimSpace(m[1]), m[2])
+			continue
+		}
+
+		im.domains[domain] = company
+	}
+
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("reading %s: %w", source, err)
+	}
+	return nil
+}
+
+// Config builds the vendor configuration from everything read so far:
+// one vendor per company (with its domains and company name) and one
+// identity per developer affiliation
+func (im *Importer) Config() (*config.Config, error) {
+	cfg := &config.Config{Vendors: make(map[string]config.VendorConfig)}
+
+	domains := make([]string, 0, len(im.domains))
+	for domain := range im.domains {
+		domains = append(domains, domain)
+	}
+	sort.Strings(domains)
+
+	for _, domain := range domains {
+		vendor := im.vendorFor(im.domains[domain])
+		if vendor == "" || vendor == "community" {
+			continue
+		}
+		v := cfg.Vendors[vendor]
+		v.Domains = append(v.Domains, domain)
+		cfg.Vendors[vendor] = v
+	}
+
+	keys := make([]string, 0, len(im.developers))
+	for key := range im.developers {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		dev := im.developers[key]
+		if len(dev.emails) == 0 {
+			im.warnf("%s has no email addresses, skipped", key)
+			continue
+		}
+
+		from := ""
+		for _, aff := range dev.affiliations {
+			identity, err := identityFor(dev, aff, from)
+			if err != nil {
+				return nil, err
+			}
+			from = aff.until
+
+			identity.Vendor = im.vendorFor(aff.company)
+			if identity.Vendor == "" {
+				continue
+			}
+			cfg.Identities = append(cfg.Identities, identity)
+
+			if identity.Vendor != "community" {
+				if _, ok := cfg.Vendors[identity.Vendor]; !ok {
+					cfg.Vendors[identity.Vendor] = config.VendorConfig{}
+				}
+			}
+		}
+	}
+
+	// Company names let github_companies rules match profile data too
+	for vendor, v := range cfg.Vendors {
+		v.GithubCompanies = []string{im.companies[vendor]}
+		cfg.Vendors[vendor] = v
+	}
+
+	return cfg, nil
+}
+
+// identityFor converts one affiliation into a time-bounded identity
+func identityFor(dev *developer, aff affiliation, from string) (config.IdentityConfig, error) {
+	identity := config.IdentityConfig{
+		Emails: dev.emails,
+		From:   from,
+	}
+
+	if aff.until != "" {
+		end, err := time.Parse("2006-01-02", aff.until)
+		if err != nil {
+			return identity, fmt.Errorf("%s: invalid date %q: %w", dev.key, aff.until, err)
+		}
+		identity.Until = end.AddDate(0, 0, -1).Format("2006-01-02")
+	}
+
+	return identity, nil
+}
+
+// vendorFor returns the vendor key of a company, "community" for
+// independent developers and "" for unknown affiliations
+func (im *Importer) vendorFor(company string) string {
+	lower := strings.ToLower(strings.TrimSpace(company))
+	switch {
+	case unknownCompanies[lower]:
+		return ""
+	case communityCompanies[lower]:
+		return "community"
+	}
+
+	key := VendorKey(company)
+	if key == "" {
+		return ""
+	}
+	if _, ok := im.companies[key]; !ok {
+		im.companies[key] = strings.TrimSpace(company)
+	}
+	return key
+}
+
+// VendorKey derives a config vendor key from a company name
+// (e.g. "Red Hat, Inc." -> "red-hat")
+func VendorKey(company string) string {
+	words := strings.Fields(strings.ToLower(company))
+	for len(words) > 1 {
+		last := strings.Trim(words[len(words)-1], ".,")
+		if !containsString(legalSuffixes, last) {
+			break
+		}
+		words = words[:len(words)-1]
+	}
+
+	key := slugPattern.ReplaceAllString(strings.Join(words, " "), "-")
+	return strings.Trim(key, "-")
+}
+
+// warnf records a non-fatal problem found while importing
+func (im *Importer) warnf(format string, args ...interface{}) {
+	im.Warnings = append(im.Warnings, fmt.Sprintf(format, args...))
+}
+
+func containsString(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,69 @@
+// Package palette assigns vendor colors shared by the terminal display and
+// the exported charts, so a vendor keeps its color across every renderer
+package palette
+
+import (
+	"regexp"
+	"strings"
+)
+
+// Colors is the sequence given to vendors in ranking order
+var Colors = []string{
+	"#e4572e", "#2e86de", "#17a589", "#f1c40f", "#8e44ad",
+	"#16a2b8", "#e67e22", "#c0392b", "#27ae60", "#5d6d7e",
+}
+
+// Fixed colors of the community and "others" buckets
+const (
+	Community = "#9aa5b1"
+	Others    = "#d5d8dc"
+)
+
+// hexPattern matches "#rgb" and "#rrggbb" colors
+var hexPattern = regexp.MustCompile(`^#([0-9a-fA-F]{3}|[0-9a-fA-F]{6})$`)
+
+// IsHex reports whether color is a "#rgb" or "#rrggbb" hex color
+func IsHex(color string) bool {
+	return hexPattern.MatchString(color)
+}
+
+// Assign gives each name a color. Pinned colors win; community and others
+// are always gray; the remaining names take palette colors in the given order, skipping
+// colors already pinned to another vendor. Callers pass names sorted by a
+// deterministic ranking so the same data always gets the same colors.
+func Assign(names []string, pinned map[string]string) map[string]string {
+	taken := make(map[string]bool, len(pinned))
+	for _, color := range pinned {
+		taken[strings.ToLower(color)] = true
+	}
+
+	available := make([]string, 0, len(Colors))
+	for _, color := range Colors {
+		if !taken[color] {
+			available = append(available, color)
+		}
+	}
+	if len(available) == 0 {
+		available = Colors
+	}
+
+	colors := make(map[string]string, len(names))
+	i := 0
+	for _, name := range names {
+		if color, ok := pinned[name]; ok {
+			colors[name] = color
+			continue
+		}
+		switch name {
+		case "community":
+			colors[name] = Community
+			continue
+		case "others":
+			colors[name] = Others
+			continue
+		}
+		colors[name] = available[i%len(available)]
+		i++
+	}
+	return colors
+}
@@ -0,0 +1,231 @@
+package profiles
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// DefaultAPIURL is the public GitHub REST API endpoint
+const DefaultAPIURL = "https://api.github.com"
+
+// ErrRateLimited is returned when the API refuses further requests
+var ErrRateLimited = errors.New("API rate limit exceeded")
+
+// repoPart matches one segment of an owner/name repository
+var repoPart = regexp.MustCompile(`^[A-Za-z0-9_.-]+$`)
+
+// StatusError is returned when the API answers with an unexpected status
+type StatusError struct {
+	Path       string
+	StatusCode int
+	Status     string
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("request %s failed: %s", e.Path, e.Status)
+}
+
+// fatal reports whether the status means no further request can succeed:
+// a rejected token, missing access or a repository that does not exist
+func (e *StatusError) fatal() bool {
+	switch e.StatusCode {
+	case http.StatusUnauthorized, http.StatusForbidden, http.StatusNotFound:
+		return true
+	}
+	return false
+}
+
+// ParseRepo extracts "owner/name" from a repository given as owner/name or
+// as an HTTPS or SSH remote URL (git@github.com:owner/name.git)
+func ParseRepo(repo string) (string, error) {
+	path := strings.TrimSpace(repo)
+	if i := strings.Index(path, "://"); i >= 0 {
+		u, err := url.Parse(path)
+		if err != nil {
+			return "", fmt.Errorf("invalid repository URL %q: %w", repo, err)
+		}
+		path = u.Path
+	} else if i := strings.Index(path, ":"); i >= 0 {
+		// scp-like SSH remote: [user@]host:owner/name
+		path = path[i+1:]
+	}
+	path = strings.TrimSuffix(strings.Trim(path, "/"), ".git")
+
+	parts := strings.Split(path, "/")
+	if len(parts) != 2 || !repoPart.MatchString(parts[0]) || !repoPart.MatchString(parts[1]) {
+		return "", fmt.Errorf("cannot determine the GitHub repository from %q (expected owner/name)", repo)
+	}
+	return parts[0] + "/" + parts[1], nil
+}
+
+// Author identifies a commit author to look up, with one of their commits
+// so the API can resolve the email to a GitHub account
+type Author struct {
+	Email string
+	Name  string
+	SHA   string
+}
+
+// Client fetches profiles from a GitHub-compatible REST API
+type Client struct {
+	baseURL    string
+	token      string
+	httpClient *http.Client
+}
+
+// NewClient creates a new API client. An empty baseURL uses DefaultAPIURL.
+func NewClient(baseURL, token string) *Client {
+	if baseURL == "" {
+		baseURL = DefaultAPIURL
+	}
+	return &Client{
+		baseURL:    strings.TrimRight(baseURL, "/"),
+		token:      token,
+		httpClient: &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+// FetchCallback is called after each author is looked up
+type FetchCallback func(processed, total int)
+
+// FetchProfiles resolves each author to a GitHub login (from their noreply
+// address or the commit's author on repo "owner/name") and reads the
+// account's company. Authors without a linked account are skipped. On rate
+// limiting the profiles fetched so far are returned with ErrRateLimited; a
+// rejected token or unknown repository aborts the run. Other failed lookups
+// are skipped and returned together once every author has been tried.
+func (c *Client) FetchProfiles(repo string, authors []Author, progressCallback FetchCallback) ([]*Profile, error) {
+	profiles := make([]*Profile, 0, len(authors))
+	users := make(map[string]*Profile)
+	var failures []error
+
+	for i, author := range authors {
+		if progressCallback != nil {
+			progressCallback(i+1, len(authors))
+		}
+
+		login := LoginFromNoreply(author.Email)
+		if login == "" && author.SHA != "" {
+			var err error
+			login, err = c.commitAuthorLogin(repo, author.SHA)
+			if abort(err) {
+				return profiles, err
+			}
+			if err != nil {
+				failures = append(failures, fmt.Errorf("%s: %w", author.Email, err))
+				continue
+			}
+		}
+		if login == "" {
+			continue
+		}
+
+		user, ok := users[strings.ToLower(login)]
+		if !ok {
+			var err error
+			user, err = c.user(login)
+			if abort(err) {
+				return profiles, err
+			}
+			if err != nil {
+				failures = append(failures, fmt.Errorf("%s: %w", author.Email, err))
+				continue
+			}
+			users[strings.ToLower(login)] = user
+		}
+
+		profiles = append(profiles, &Profile{
+			Email:   author.Email,
+			Login:   user.Login,
+			Name:    firstNonEmpty(user.Name, author.Name),
+			Company: NormalizeCompany(user.Company),
+		})
+	}
+
+	if len(failures) > 0 {
+		return profiles, fmt.Errorf("%d of %d lookups failed: %w", len(failures), len(authors), errors.Join(failures...))
+	}
+	return profiles, nil
+}
+
+// abort reports whether err ends a fetch: rate limiting or a fatal status
+func abort(err error) bool {
+	var status *StatusError
+	return errors.Is(err, ErrRateLimited) || (errors.As(err, &status) && status.fatal())
+}
+
+// commitAuthorLogin returns the GitHub login linked to a commit's author
+func (c *Client) commitAuthorLogin(repo, sha string) (string, error) {
+	var commit struct {
+		Author *struct {
+			Login string `json:"login"`
+		} `json:"author"`
+	}
+	if err := c.get(fmt.Sprintf("/repos/%s/commits/%s", repo, url.PathEscape(sha)), &commit); err != nil {
+		return "", err
+	}
+	if commit.Author == nil {
+		return "", nil
+	}
+	return commit.Author.Login, nil
+}
+
+// user fetches a GitHub account's public profile
+func (c *Client) user(login string) (*Profile, error) {
+	var user struct {
+		Login   string `json:"login"`
+		Name    string `json:"name"`
+		Company string `json:"company"`
+	}
+	if err := c.get("/users/"+url.PathEscape(login), &user); err != nil {
+		return nil, err
+	}
+	return &Profile{Login: user.Login, Name: user.Name, Company: user.Company}, nil
+}
+
+// get performs an authenticated GET request and decodes the JSON response
+func (c *Client) get(path string, v interface{}) error {
+	req, err := http.NewRequest(http.MethodGet, c.baseURL+path, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/vnd.github+json")
+	if c.token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("request %s failed: %w", path, err)
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusTooManyRequests,
+		resp.StatusCode == http.StatusForbidden && resp.Header.Get("X-RateLimit-Remaining") == "0":
+		return ErrRateLimited
+	case resp.StatusCode != http.StatusOK:
+		return &StatusError{Path: path, StatusCode: resp.StatusCode, Status: resp.Status}
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(v); err != nil {
+		return fmt.Errorf("failed to decode %s: %w", path, err)
+	}
+	return nil
+}
+
+// firstNonEmpty returns the first non-empty string
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
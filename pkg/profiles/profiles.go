@@ -0,0 +1,253 @@
+package profiles
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/sderosiaux/git-contributor-insights/pkg/atomicfile"
+)
+
+// Profile maps a contributor identity to a GitHub account and company
+type Profile struct {
+	Email   string `json:"email,omitempty"`
+	Login   string `json:"login,omitempty"`
+	Name    string `json:"name,omitempty"`
+	Company string `json:"company,omitempty"`
+}
+
+// csvHeader is the column order used for CSV profile files
+var csvHeader = []string{"email", "login", "name", "company"}
+
+// Store indexes profiles by email and GitHub login
+type Store struct {
+	profiles []*Profile
+	byEmail  map[string]*Profile
+	byLogin  map[string]*Profile
+}
+
+// NewStore creates a Store from a list of profiles. Later entries win when
+// several profiles share an email or login.
+func NewStore(profiles []*Profile) *Store {
+	s := &Store{
+		byEmail: make(map[string]*Profile),
+		byLogin: make(map[string]*Profile),
+	}
+	for _, p := range profiles {
+		s.Add(p)
+	}
+	return s
+}
+
+// Add inserts a profile, replacing any existing profile with the same email
+// (or the same login for profiles without an email)
+func (s *Store) Add(p *Profile) {
+	p.Company = NormalizeCompany(p.Company)
+
+	var existing *Profile
+	if p.Email != "" {
+		existing = s.byEmail[strings.ToLower(p.Email)]
+	} else if p.Login != "" {
+		existing = s.byLogin[strings.ToLower(p.Login)]
+	}
+
+	if existing != nil {
+		*existing = *p
+		p = existing
+	} else {
+		s.profiles = append(s.profiles, p)
+	}
+
+	if p.Email != "" {
+		s.byEmail[strings.ToLower(p.Email)] = p
+	}
+	if p.Login != "" {
+		s.byLogin[strings.ToLower(p.Login)] = p
+	}
+}
+
+// Len returns the number of profiles in the store
+func (s *Store) Len() int {
+	return len(s.profiles)
+}
+
+// Profiles returns the profiles sorted by email, then login
+func (s *Store) Profiles() []*Profile {
+	result := make([]*Profile, len(s.profiles))
+	copy(result, s.profiles)
+
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Email != result[j].Email {
+			return result[i].Email < result[j].Email
+		}
+		return result[i].Login < result[j].Login
+	})
+	return result
+}
+
+// Lookup finds the profile for an email address, either directly or through
+// the login embedded in a GitHub noreply address
+func (s *Store) Lookup(email string) *Profile {
+	if p, ok := s.byEmail[strings.ToLower(email)]; ok {
+		return p
+	}
+	if login := LoginFromNoreply(email); login != "" {
+		if p, ok := s.byLogin[strings.ToLower(login)]; ok {
+			return p
+		}
+	}
+	return nil
+}
+
+// Company returns the company recorded for an email address, or ""
+func (s *Store) Company(email string) string {
+	if p := s.Lookup(email); p != nil {
+		return p.Company
+	}
+	return ""
+}
+
+// LoginFromNoreply extracts the GitHub login from a noreply address such as
+// "12345+octocat@users.noreply.github.com" or "octocat@users.noreply.github.com"
+func LoginFromNoreply(email string) string {
+	lower := strings.ToLower(email)
+	if !strings.HasSuffix(lower, "@users.noreply.github.com") {
+		return ""
+	}
+	local := email[:strings.IndexByte(email, '@')]
+	if idx := strings.IndexByte(local, '+'); idx >= 0 {
+		local = local[idx+1:]
+	}
+	return local
+}
+
+// NormalizeCompany trims whitespace and the "@org" prefix GitHub users often
+// put in their company field
+func NormalizeCompany(company string) string {
+	return strings.TrimPrefix(strings.TrimSpace(company), "@")
+}
+
+// Load reads a profile file; the format is chosen by extension (.json or .csv)
+func Load(path string) (*Store, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var profiles []*Profile
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		profiles, err = readJSON(f)
+	case ".csv":
+		profiles, err = readCSV(f)
+	default:
+		return nil, fmt.Errorf("unsupported profile file %s (want .json or .csv)", path)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	return NewStore(profiles), nil
+}
+
+// Save writes profiles to a file; the format is chosen by extension (.json
+// or .csv). The existing file is only replaced once the write succeeds.
+func Save(path string, profiles []*Profile) error {
+	var write func(io.Writer, []*Profile) error
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		write = writeJSON
+	case ".csv":
+		write = writeCSV
+	default:
+		return fmt.Errorf("unsupported profile file %s (want .json or .csv)", path)
+	}
+
+	return atomicfile.Write(path, func(w io.Writer) error {
+		return write(w, profiles)
+	})
+}
+
+// readJSON reads a JSON array of profiles
+func readJSON(r io.Reader) ([]*Profile, error) {
+	var profiles []*Profile
+	if err := json.NewDecoder(r).Decode(&profiles); err != nil {
+		return nil, err
+	}
+	return profiles, nil
+}
+
+// writeJSON writes profiles as an indented JSON array
+func writeJSON(w io.Writer, profiles []*Profile) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(profiles)
+}
+
+// readCSV reads profiles from a CSV file with a header row. Columns are
+// matched by name, so any order (and extra columns) is accepted.
+func readCSV(r io.Reader) ([]*Profile, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, err
+	}
+
+	columns := make(map[string]int)
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	if _, hasEmail := columns["email"]; !hasEmail {
+		if _, hasLogin := columns["login"]; !hasLogin {
+			return nil, fmt.Errorf("CSV header needs an email or login column")
+		}
+	}
+
+	field := func(record []string, name string) string {
+		if i, ok := columns[name]; ok && i < len(record) {
+			return strings.TrimSpace(record[i])
+		}
+		return ""
+	}
+
+	profiles := make([]*Profile, 0)
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		profiles = append(profiles, &Profile{
+			Email:   field(record, "email"),
+			Login:   field(record, "login"),
+			Name:    field(record, "name"),
+			Company: field(record, "company"),
+		})
+	}
+	return profiles, nil
+}
+
+// writeCSV writes profiles with a header row
+func writeCSV(w io.Writer, profiles []*Profile) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write(csvHeader); err != nil {
+		return err
+	}
+	for _, p := range profiles {
+		if err := writer.Write([]string{p.Email, p.Login, p.Name, p.Company}); err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
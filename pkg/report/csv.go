@@ -0,0 +1,172 @@
+package report
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/sderosiaux/git-contributor-insights/pkg/atomicfile"
+)
+
+// CSV file names written by WriteCSV
+const (
+	VendorsCSV      = "vendors.csv"
+	TimelineCSV     = "timeline.csv"
+	TimelineLongCSV = "timeline_long.csv"
+)
+
+// csvFile pairs a CSV file name with the function rendering it
+type csvFile struct {
+	name  string
+	write func(io.Writer, *Report) error
+}
+
+// WriteCSV writes the vendor breakdown and, with a timeline, the per-period
+// matrices into dir. Numbers are raw (no separators or ANSI codes).
+// It returns the paths of the files written.
+func WriteCSV(dir string, r *Report) ([]string, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, err
+	}
+
+	files := []csvFile{{VendorsCSV, WriteVendorsCSV}}
+	if r.Timeline != nil {
+		files = append(files, csvFile{TimelineCSV, WriteTimelineCSV}, csvFile{TimelineLongCSV, WriteTimelineLongCSV})
+	}
+
+	paths := make([]string, 0, len(files))
+	for _, file := range files {
+		path := filepath.Join(dir, file.name)
+		if err := writeFile(path, r, file.write); err != nil {
+			return paths, err
+		}
+		paths = append(paths, path)
+	}
+	return paths, nil
+}
+
+// writeFile renders into path, replacing it only once the write succeeds
+func writeFile(path string, r *Report, write func(io.Writer, *Report) error) error {
+	return atomicfile.Write(path, func(w io.Writer) error {
+		if err := write(w, r); err != nil {
+			return fmt.Errorf("writing %s: %w", path, err)
+		}
+		return nil
+	})
+}
+
+// WriteVendorsCSV writes the Vendor/Community Breakdown table
+func WriteVendorsCSV(w io.Writer, r *Report) error {
+	out := csv.NewWriter(w)
+	out.Write([]string{"category", "commits", "commits_pct", "contributors", "contributors_pct",
+		"additions", "additions_pct", "deletions", "net_change"})
+
+	totalAdditions := r.totalAdditions()
+	for _, m := range r.Vendors() {
+		out.Write([]string{
+			safeCell(m.Name),
+			strconv.Itoa(m.TotalCommits),
+			formatPercent(Percent(m.TotalCommits, r.Analysis.TotalCommits)),
+			strconv.Itoa(m.ContributorCount()),
+			formatPercent(Percent(m.ContributorCount(), r.Analysis.TotalContributors)),
+			strconv.Itoa(m.TotalAdditions),
+			formatPercent(Percent(m.TotalAdditions, totalAdditions)),
+			strconv.Itoa(m.TotalDeletions),
+			strconv.Itoa(m.NetChanges()),
+		})
+	}
+
+	out.Flush()
+	return out.Error()
+}
+
+// WriteTimelineCSV writes the period x vendor commits matrix, one row per period
+func WriteTimelineCSV(w io.Writer, r *Report) error {
+	out := csv.NewWriter(w)
+	vendors := r.TimelineVendors()
+
+	header := []string{"period", "start", "end", "total_commits"}
+	for _, vendor := range vendors {
+		header = append(header, safeCell(vendor))
+	}
+	out.Write(header)
+
+	for _, period := range r.Timeline.Periods {
+		row := []string{
+			period.Period,
+			period.StartDate.Format("2006-01-02"),
+			period.EndDate.Format("2006-01-02"),
+			strconv.Itoa(period.TotalCommits),
+		}
+		for _, vendor := range vendors {
+			commits := 0
+			if m, ok := period.VendorMetrics[vendor]; ok {
+				commits = m.TotalCommits
+			}
+			row = append(row, strconv.Itoa(commits))
+		}
+		out.Write(row)
+	}
+
+	out.Flush()
+	return out.Error()
+}
+
+// WriteTimelineLongCSV writes one row per period and vendor with every
+// metric, the layout pivot tables expect
+func WriteTimelineLongCSV(w io.Writer, r *Report) error {
+	out := csv.NewWriter(w)
+	out.Write([]string{"period", "start", "end", "vendor", "commits", "commits_pct",
+		"contributors", "additions", "deletions"})
+
+	vendors := r.TimelineVendors()
+	for _, period := range r.Timeline.Periods {
+		for _, vendor := range vendors {
+			m, ok := period.VendorMetrics[vendor]
+			if !ok || m.TotalCommits == 0 {
+				continue
+			}
+			out.Write([]string{
+				period.Period,
+				period.StartDate.Format("2006-01-02"),
+				period.EndDate.Format("2006-01-02"),
+				safeCell(vendor),
+				strconv.Itoa(m.TotalCommits),
+				formatPercent(period.GetVendorPercentage(vendor, "commits")),
+				strconv.Itoa(m.ContributorCount()),
+				strconv.Itoa(m.TotalAdditions),
+				strconv.Itoa(m.TotalDeletions),
+			})
+		}
+	}
+
+	out.Flush()
+	return out.Error()
+}
+
+// domainVendor matches auto-classified "@domain" vendor names. They hold no
+// formula syntax, so they are written unchanged and stay equal to the vendor
+// keys of the other outputs and the SQLite export.
+var domainVendor = regexp.MustCompile(`^@[a-z0-9.-]+$`)
+
+// safeCell prefixes text that spreadsheets would evaluate as a formula with
+// a quote, except for "@domain" vendor names
+func safeCell(s string) string {
+	if s == "" || domainVendor.MatchString(s) {
+		return s
+	}
+	if strings.ContainsRune("=+-@\t\r", rune(s[0])) {
+		return "'" + s
+	}
+	return s
+}
+
+// formatPercent prints a percentage with two decimals and no sign
+func formatPercent(v float64) string {
+	return strconv.FormatFloat(v, 'f', 2, 64)
+}
@@ -0,0 +1,188 @@
+package report
+
+import (
+	"fmt"
+	"html/template"
+	"io"
+
+	"github.com/sderosiaux/git-contributor-insights/pkg/analyzer"
+	"github.com/sderosiaux/git-contributor-insights/pkg/chart"
+	"github.com/sderosiaux/git-contributor-insights/pkg/types"
+)
+
+// htmlVendor is a summary table row
+type htmlVendor struct {
+	Name            string
+	Color           string
+	Metrics         *types.VendorMetrics
+	CommitsPct      float64
+	AdditionsPct    float64
+	ContributorsPct float64
+}
+
+// htmlPeriod is a timeline table row
+type htmlPeriod struct {
+	Period  string
+	Total   int
+	Commits []int
+}
+
+// htmlData is the data passed to the HTML template
+type htmlData struct {
+	*Report
+	Vendors         []htmlVendor
+	Charts          []template.HTML
+	TimelineCharts  []template.HTML
+	TimelineVendors []string
+	Periods         []htmlPeriod
+}
+
+// WriteHTML renders the report as a single self-contained HTML page with
+// inline SVG charts and no external assets
+func WriteHTML(w io.Writer, r *Report) error {
+	colors := r.Colors()
+	vendors := r.Vendors()
+	totalAdditions := r.totalAdditions()
+
+	data := htmlData{Report: r}
+	commits := make([]chart.Slice, 0, len(vendors))
+	additions := make([]chart.Slice, 0, len(vendors))
+	contributors := make([]chart.Slice, 0, len(vendors))
+
+	for _, m := range vendors {
+		data.Vendors = append(data.Vendors, htmlVendor{
+			Name:            m.Name,
+			Color:           colors[m.Name],
+			Metrics:         m,
+			CommitsPct:      Percent(m.TotalCommits, r.Analysis.TotalCommits),
+			AdditionsPct:    Percent(m.TotalAdditions, totalAdditions),
+			ContributorsPct: Percent(m.ContributorCount(), r.Analysis.TotalContributors),
+		})
+		commits = append(commits, chart.Slice{Label: m.Name, Value: float64(m.TotalCommits), Color: colors[m.Name]})
+		additions = append(additions, chart.Slice{Label: m.Name, Value: float64(m.TotalAdditions), Color: colors[m.Name]})
+		contributors = append(contributors, chart.Slice{Label: m.Name, Value: float64(m.ContributorCount()), Color: colors[m.Name]})
+	}
+
+	chart.SortSlices(additions)
+	chart.SortSlices(contributors)
+	data.Charts = []template.HTML{
+		template.HTML(chart.Pie("Commits", commits, 220)),
+		template.HTML(chart.Pie("Lines Added", additions, 220)),
+		template.HTML(chart.Pie("Contributors", contributors, 220)),
+		template.HTML(chart.Bars("Commits by Vendor", commits, 720)),
+	}
+
+	if r.Timeline != nil && len(r.Timeline.Periods) > 0 {
+		data.TimelineVendors = r.TimelineVendors()
+		data.TimelineCharts, data.Periods = timelineSections(r, data.TimelineVendors)
+	}
+
+	return htmlTemplate.Execute(w, data)
+}
+
+// timelineSections builds the stacked-area charts and table rows of a timeline
+func timelineSections(r *Report, vendors []string) ([]template.HTML, []htmlPeriod) {
+	periods := make([]htmlPeriod, 0, len(r.Timeline.Periods))
+	for _, period := range r.Timeline.Periods {
+		row := htmlPeriod{Period: period.Period, Total: period.TotalCommits}
+		for _, vendor := range vendors {
+			commits := 0
+			if m, ok := period.VendorMetrics[vendor]; ok {
+				commits = m.TotalCommits
+			}
+			row.Commits = append(row.Commits, commits)
+		}
+		periods = append(periods, row)
+	}
+
+	breakdown := r.Timeline.Breakdown
+	labels, commits, contributors := r.timelineSeries()
+	charts := []template.HTML{
+		template.HTML(chart.StackedArea("Commits per "+breakdown, labels, commits, 900, 320, false)),
+		template.HTML(chart.StackedArea("Commit share per "+breakdown, labels, commits, 900, 320, true)),
+		template.HTML(chart.StackedArea("Contributors per "+breakdown, labels, contributors, 900, 320, false)),
+	}
+	return charts, periods
+}
+
+var htmlTemplate = template.Must(template.New("report").Funcs(template.FuncMap{
+	"formatNumber": analyzer.FormatNumber,
+	"percent": func(v float64) string {
+		return fmt.Sprintf("%.1f%%", v)
+	},
+	"date": func(d types.DateRange) string {
+		return d.Start.Format("2006-01-02") + " to " + d.End.Format("2006-01-02")
+	},
+	"identity": (*types.ContributorData).Identity,
+}).Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>{{.Analysis.RepoName}} - Contributor Analysis</title>
+<style>
+body{font-family:-apple-system,"Segoe UI",Helvetica,Arial,sans-serif;color:#222;max-width:1100px;margin:2em auto;padding:0 1em}
+h1{margin-bottom:.2em}h2{margin-top:2em;border-bottom:1px solid #ddd;padding-bottom:.3em}
+.meta{color:#666}.cards{display:flex;gap:1em;margin:1.5em 0}
+.card{flex:1;border:1px solid #ddd;border-radius:6px;padding:.8em 1em}.card b{display:block;font-size:1.6em}
+table{border-collapse:collapse;width:100%;font-size:.92em}th,td{padding:.35em .6em;border-bottom:1px solid #eee;text-align:right}
+th:first-child,td:first-child{text-align:left}th{background:#fafafa}
+.swatch{display:inline-block;width:.8em;height:.8em;margin-right:.4em;border-radius:2px;vertical-align:baseline}
+.charts{display:flex;flex-wrap:wrap;gap:1.5em;margin-top:1em}footer{margin-top:3em;color:#888;font-size:.85em}
+</style>
+</head>
+<body>
+<h1>{{.Analysis.RepoName}}</h1>
+<p class="meta">{{date .Analysis.DateRange}}</p>
+<div class="cards">
+<div class="card">Commits<b>{{formatNumber .Analysis.TotalCommits}}</b></div>
+<div class="card">Contributors<b>{{formatNumber .Analysis.TotalContributors}}</b></div>
+<div class="card">Categories<b>{{len .Vendors}}</b></div>
+</div>
+
+<h2>Vendor/Community Breakdown</h2>
+<table>
+<tr><th>Category</th><th>Commits</th><th>% Commits</th><th>Contributors</th><th>% Contributors</th><th>Lines Added</th><th>% Added</th><th>Lines Deleted</th><th>Net Change</th></tr>
+{{- range .Vendors}}
+<tr><td><span class="swatch" style="background:{{.Color}}"></span>{{.Name}}</td><td>{{formatNumber .Metrics.TotalCommits}}</td><td>{{percent .CommitsPct}}</td><td>{{formatNumber .Metrics.ContributorCount}}</td><td>{{percent .ContributorsPct}}</td><td>+{{formatNumber .Metrics.TotalAdditions}}</td><td>{{percent .AdditionsPct}}</td><td>-{{formatNumber .Metrics.TotalDeletions}}</td><td>{{formatNumber .Metrics.NetChanges}}</td></tr>
+{{- end}}
+</table>
+
+<h2>Distribution</h2>
+<div class="charts">
+{{- range .Charts}}
+<div>{{.}}</div>
+{{- end}}
+</div>
+{{- if .Periods}}
+
+<h2>Timeline ({{.Timeline.Breakdown}})</h2>
+<div class="charts">
+{{- range .TimelineCharts}}
+<div>{{.}}</div>
+{{- end}}
+</div>
+<table>
+<tr><th>Period</th><th>Total</th>{{range .TimelineVendors}}<th>{{.}}</th>{{end}}</tr>
+{{- range .Periods}}
+<tr><td>{{.Period}}</td><td>{{formatNumber .Total}}</td>{{range .Commits}}<td>{{if .}}{{formatNumber .}}{{else}}-{{end}}</td>{{end}}</tr>
+{{- end}}
+</table>
+{{- end}}
+{{- if gt .TopContributors 0}}
+
+<h2>Top Contributors</h2>
+{{- range .Vendors}}
+<h3><span class="swatch" style="background:{{.Color}}"></span>{{.Name}}</h3>
+<table>
+<tr><th>Contributor</th><th>Commits</th><th>Lines Added</th><th>Lines Deleted</th><th>First</th><th>Last</th></tr>
+{{- range .Metrics.TopContributors $.TopContributors}}
+<tr><td>{{identity .}}</td><td>{{formatNumber .Commits}}</td><td>+{{formatNumber .Additions}}</td><td>-{{formatNumber .Deletions}}</td><td>{{.FirstCommit.Format "2006-01-02"}}</td><td>{{.LastCommit.Format "2006-01-02"}}</td></tr>
+{{- end}}
+</table>
+{{- end}}
+{{- end}}
+
+<footer>Generated {{.GeneratedAt.Format "2006-01-02 15:04"}} by Git Contributor Insights</footer>
+</body>
+</html>
+`))
@@ -0,0 +1,226 @@
+package report
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/sderosiaux/git-contributor-insights/pkg/analyzer"
+	"github.com/sderosiaux/git-contributor-insights/pkg/types"
+)
+
+// WriteMarkdown renders the report as GitHub-flavored Markdown. With
+// mermaid set, Mermaid pie and xychart blocks are added next to the tables.
+func WriteMarkdown(w io.Writer, r *Report, mermaid bool) error {
+	out := bufio.NewWriter(w)
+
+	a := r.Analysis
+	fmt.Fprintf(out, "# %s: Contributor Analysis\n\n", escapeMarkdown(a.RepoName))
+	fmt.Fprintf(out, "**Commits:** %s · **Contributors:** %s · **Date range:** %s to %s\n\n",
+		analyzer.FormatNumber(a.TotalCommits),
+		analyzer.FormatNumber(a.TotalContributors),
+		a.DateRange.Start.Format("2006-01-02"),
+		a.DateRange.End.Format("2006-01-02"),
+	)
+
+	writeMarkdownBreakdown(out, r, mermaid)
+
+	if r.TopContributors > 0 {
+		writeMarkdownContributors(out, r)
+	}
+
+	writeMarkdownInsights(out, r)
+
+	if r.Timeline != nil && len(r.Timeline.Periods) > 0 {
+		writeMarkdownTimeline(out, r, mermaid)
+	}
+
+	return out.Flush()
+}
+
+// writeMarkdownBreakdown writes the Vendor/Community Breakdown table
+func writeMarkdownBreakdown(out io.Writer, r *Report, mermaid bool) {
+	fmt.Fprint(out, "## Vendor/Community Breakdown\n\n")
+	fmt.Fprint(out, "| Category | Commits | % Commits | Contributors | % Contributors | Lines Added | Lines Deleted | Net Change |\n")
+	fmt.Fprint(out, "|---|--:|--:|--:|--:|--:|--:|--:|\n")
+
+	vendors := r.Vendors()
+	for _, m := range vendors {
+		fmt.Fprintf(out, "| %s | %s | %.1f%% | %s | %.1f%% | +%s | -%s | %s |\n",
+			escapeMarkdown(m.Name),
+			analyzer.FormatNumber(m.TotalCommits),
+			Percent(m.TotalCommits, r.Analysis.TotalCommits),
+			analyzer.FormatNumber(m.ContributorCount()),
+			Percent(m.ContributorCount(), r.Analysis.TotalContributors),
+			analyzer.FormatNumber(m.TotalAdditions),
+			analyzer.FormatNumber(m.TotalDeletions),
+			analyzer.FormatNumber(m.NetChanges()),
+		)
+	}
+	fmt.Fprintln(out)
+
+	if mermaid {
+		fmt.Fprint(out, "```mermaid\npie showData title Commits by category\n")
+		for _, m := range vendors {
+			fmt.Fprintf(out, "    %s : %d\n", mermaidString(m.Name), m.TotalCommits)
+		}
+		fmt.Fprint(out, "```\n\n")
+	}
+}
+
+// writeMarkdownContributors writes the top people of each vendor
+func writeMarkdownContributors(out io.Writer, r *Report) {
+	fmt.Fprint(out, "## Top Contributors\n")
+	for _, m := range r.Vendors() {
+		fmt.Fprintf(out, "\n### %s (%s contributors)\n\n", escapeMarkdown(m.Name), analyzer.FormatNumber(m.ContributorCount()))
+		fmt.Fprint(out, "| Contributor | Commits | Lines Added | Lines Deleted | First | Last |\n")
+		fmt.Fprint(out, "|---|--:|--:|--:|---|---|\n")
+		for _, c := range m.TopContributors(r.TopContributors) {
+			fmt.Fprintf(out, "| %s | %s | +%s | -%s | %s | %s |\n",
+				escapeMarkdown(c.Identity()),
+				analyzer.FormatNumber(c.Commits),
+				analyzer.FormatNumber(c.Additions),
+				analyzer.FormatNumber(c.Deletions),
+				c.FirstCommit.Format("2006-01-02"),
+				c.LastCommit.Format("2006-01-02"),
+			)
+		}
+	}
+	fmt.Fprintln(out)
+}
+
+// writeMarkdownInsights writes the Key Insights list
+func writeMarkdownInsights(out io.Writer, r *Report) {
+	fmt.Fprint(out, "## Key Insights\n\n")
+
+	a := r.Analysis
+	if vendors := r.Vendors(); len(vendors) > 0 {
+		top := vendors[0]
+		fmt.Fprintf(out, "- 🏆 **%s** leads with %.1f%% of commits (%s commits)\n",
+			escapeMarkdown(top.Name), Percent(top.TotalCommits, a.TotalCommits), analyzer.FormatNumber(top.TotalCommits))
+	}
+
+	if community, ok := a.VendorMetrics["community"]; ok {
+		fmt.Fprintf(out, "- 🌍 Community contributes %.1f%% of commits with %s contributors\n",
+			Percent(community.TotalCommits, a.TotalCommits), analyzer.FormatNumber(community.ContributorCount()))
+	}
+
+	totalChanges := 0
+	for _, m := range a.VendorMetrics {
+		totalChanges += m.TotalAdditions + m.TotalDeletions
+	}
+	avgSize := 0
+	if a.TotalCommits > 0 {
+		avgSize = totalChanges / a.TotalCommits
+	}
+	fmt.Fprintf(out, "- 📏 Average commit size: %s lines changed\n\n", analyzer.FormatNumber(avgSize))
+}
+
+// writeMarkdownTimeline writes the period table and Key Trends
+func writeMarkdownTimeline(out io.Writer, r *Report, mermaid bool) {
+	timeline := r.Timeline
+	vendors := r.TimelineVendors()
+
+	fmt.Fprintf(out, "## Timeline (%s)\n\n", timeline.Breakdown)
+
+	fmt.Fprint(out, "| Period | Total |")
+	for _, vendor := range vendors {
+		fmt.Fprintf(out, " %s |", escapeMarkdown(vendor))
+	}
+	fmt.Fprint(out, "\n|---|--:|"+strings.Repeat("--:|", len(vendors))+"\n")
+
+	for _, period := range timeline.Periods {
+		fmt.Fprintf(out, "| %s | %s |", period.Period, analyzer.FormatNumber(period.TotalCommits))
+		for _, vendor := range vendors {
+			m, ok := period.VendorMetrics[vendor]
+			if !ok || m.TotalCommits == 0 {
+				fmt.Fprint(out, " - |")
+				continue
+			}
+			fmt.Fprintf(out, " %s (%.0f%%) |", analyzer.FormatNumber(m.TotalCommits), period.GetVendorPercentage(vendor, "commits"))
+		}
+		fmt.Fprintln(out)
+	}
+	fmt.Fprintln(out)
+
+	if mermaid {
+		labels := make([]string, 0, len(timeline.Periods))
+		totals := make([]string, 0, len(timeline.Periods))
+		community := make([]string, 0, len(timeline.Periods))
+		for _, period := range timeline.Periods {
+			labels = append(labels, mermaidString(period.Period))
+			totals = append(totals, fmt.Sprintf("%d", period.TotalCommits))
+			n := 0
+			if m, ok := period.VendorMetrics["community"]; ok {
+				n = m.TotalCommits
+			}
+			community = append(community, fmt.Sprintf("%d", n))
+		}
+
+		fmt.Fprint(out, "```mermaid\nxychart-beta\n")
+		fmt.Fprintf(out, "    title \"Commits per %s (bars: total, line: community)\"\n", timeline.Breakdown)
+		fmt.Fprintf(out, "    x-axis [%s]\n", strings.Join(labels, ", "))
+		fmt.Fprint(out, "    y-axis \"Commits\"\n")
+		fmt.Fprintf(out, "    bar [%s]\n", strings.Join(totals, ", "))
+		fmt.Fprintf(out, "    line [%s]\n", strings.Join(community, ", "))
+		fmt.Fprint(out, "```\n\n")
+	}
+
+	first := timeline.Periods[0]
+	last := timeline.Periods[len(timeline.Periods)-1]
+
+	fmt.Fprint(out, "## Key Trends\n\n")
+	fmt.Fprintf(out, "- 📈 Period range: %s → %s\n", first.Period, last.Period)
+	fmt.Fprintf(out, "- 📊 Commits: %s → %s (%+d)\n",
+		analyzer.FormatNumber(first.TotalCommits), analyzer.FormatNumber(last.TotalCommits), last.TotalCommits-first.TotalCommits)
+
+	if _, ok := first.VendorMetrics["community"]; ok {
+		firstPct := first.GetVendorPercentage("community", "commits")
+		lastPct := last.GetVendorPercentage("community", "commits")
+		fmt.Fprintf(out, "- 🌍 Community: %.1f%% → %.1f%% %s\n", firstPct, lastPct, trendArrow(lastPct-firstPct))
+		fmt.Fprintf(out, "  - Contributors: %d → %d\n",
+			contributorsIn(first.VendorMetrics["community"]), contributorsIn(last.VendorMetrics["community"]))
+	}
+	fmt.Fprintln(out)
+}
+
+// contributorsIn counts a vendor's contributors, tolerating missing metrics
+func contributorsIn(m *types.VendorMetrics) int {
+	if m == nil {
+		return 0
+	}
+	return m.ContributorCount()
+}
+
+// trendArrow returns the arrow used for a change in share
+func trendArrow(change float64) string {
+	switch {
+	case change > 0:
+		return "↗"
+	case change < 0:
+		return "↘"
+	default:
+		return "→"
+	}
+}
+
+// markdownEscaper escapes formatting, link and table syntax in inline text,
+// and "@" so "@domain" vendors are not rendered as mentions on GitHub
+var markdownEscaper = strings.NewReplacer(
+	`\`, `\\`, "*", `\*`, "_", `\_`, "`", "\\`", "[", `\[`, "]", `\]`,
+	"#", `\#`, "|", `\|`, "<", "&lt;", ">", "&gt;", "@", "&#64;",
+)
+
+// escapeMarkdown escapes names so they render literally in text and tables
+func escapeMarkdown(s string) string {
+	return markdownEscaper.Replace(s)
+}
+
+// mermaidString quotes a Mermaid label; Mermaid has no backslash escapes,
+// so quotes become the #quot; entity and line breaks become spaces. Labels
+// sit in a fenced block, which GitHub does not scan for mentions, so "@"
+// stays as is.
+func mermaidString(s string) string {
+	return `"` + strings.NewReplacer(`"`, "#quot;", "\n", " ", "\r", "").Replace(s) + `"`
+}
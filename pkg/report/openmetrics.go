@@ -0,0 +1,105 @@
+package report
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// metricFamily is an OpenMetrics gauge family with its samples. Totals are
+// gauges too: they cover the --since/--until window and can go down.
+type metricFamily struct {
+	name    string
+	help    string
+	samples []metricSample
+}
+
+// metricSample is a single labelled value
+type metricSample struct {
+	labels [][2]string
+	value  float64
+}
+
+// labelEscaper escapes label values as required by the exposition format
+var labelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+// WriteOpenMetrics renders the report in the OpenMetrics text format, e.g.
+// for the node_exporter textfile collector. Contributor gauges carry a
+// period label: "all" for the whole range plus one per timeline period.
+func WriteOpenMetrics(w io.Writer, r *Report) error {
+	a := r.Analysis
+	repo := [2]string{"repo", a.RepoName}
+
+	commits := metricFamily{name: "ghca_commits", help: "Commits by vendor over the analyzed range"}
+	additions := metricFamily{name: "ghca_additions", help: "Lines added by vendor over the analyzed range"}
+	deletions := metricFamily{name: "ghca_deletions", help: "Lines deleted by vendor over the analyzed range"}
+	contributors := metricFamily{name: "ghca_contributors", help: "Unique contributors by vendor and period"}
+	periodCommits := metricFamily{name: "ghca_period_commits", help: "Commits by vendor in each timeline period"}
+
+	for _, m := range r.Vendors() {
+		vendor := [2]string{"vendor", m.Name}
+		commits.add(float64(m.TotalCommits), repo, vendor)
+		additions.add(float64(m.TotalAdditions), repo, vendor)
+		deletions.add(float64(m.TotalDeletions), repo, vendor)
+		contributors.add(float64(m.ContributorCount()), repo, vendor, [2]string{"period", "all"})
+	}
+
+	if r.Timeline != nil {
+		for _, period := range r.Timeline.Periods {
+			for _, name := range r.TimelineVendors() {
+				m, ok := period.VendorMetrics[name]
+				if !ok || m.TotalCommits == 0 {
+					continue
+				}
+				labels := [][2]string{repo, {"vendor", name}, {"period", period.Period}}
+				contributors.add(float64(m.ContributorCount()), labels...)
+				periodCommits.add(float64(m.TotalCommits), labels...)
+			}
+		}
+	}
+
+	share := metricFamily{name: "ghca_community_share", help: "Share of commits from the community (0-1)"}
+	communityCommits := 0
+	if community, ok := a.VendorMetrics["community"]; ok {
+		communityCommits = community.TotalCommits
+	}
+	share.add(Percent(communityCommits, a.TotalCommits)/100, repo)
+
+	repoContributors := metricFamily{name: "ghca_repo_contributors", help: "Unique contributors over the analyzed range"}
+	repoContributors.add(float64(a.TotalContributors), repo)
+
+	timestamp := metricFamily{name: "ghca_report_timestamp_seconds", help: "Time the analysis was generated"}
+	timestamp.add(float64(r.GeneratedAt.Unix()), repo)
+
+	out := bufio.NewWriter(w)
+	for _, family := range []metricFamily{commits, additions, deletions, contributors, periodCommits, share, repoContributors, timestamp} {
+		family.write(out)
+	}
+	fmt.Fprintln(out, "# EOF")
+	return out.Flush()
+}
+
+// add appends a sample to the family
+func (f *metricFamily) add(value float64, labels ...[2]string) {
+	f.samples = append(f.samples, metricSample{labels: labels, value: value})
+}
+
+// write renders the family metadata and samples
+func (f *metricFamily) write(out io.Writer) {
+	if len(f.samples) == 0 {
+		return
+	}
+
+	fmt.Fprintf(out, "# TYPE %s gauge\n", f.name)
+	fmt.Fprintf(out, "# HELP %s %s\n", f.name, f.help)
+
+	for _, s := range f.samples {
+		pairs := make([]string, 0, len(s.labels))
+		for _, l := range s.labels {
+			pairs = append(pairs, fmt.Sprintf(`%s="%s"`, l[0], labelEscaper.Replace(l[1])))
+		}
+		fmt.Fprintf(out, "%s{%s} %s\n", f.name, strings.Join(pairs, ","), strconv.FormatFloat(s.value, 'g', -1, 64))
+	}
+}
@@ -0,0 +1,86 @@
+package report
+
+import (
+	"time"
+
+	"github.com/sderosiaux/git-contributor-insights/pkg/analyzer"
+	"github.com/sderosiaux/git-contributor-insights/pkg/palette"
+	"github.com/sderosiaux/git-contributor-insights/pkg/types"
+)
+
+// Output formats accepted by --output
+const (
+	FormatText        = "text"
+	FormatHTML        = "html"
+	FormatCSV         = "csv"
+	FormatMarkdown    = "markdown"
+	FormatSVG         = "svg"
+	FormatOpenMetrics = "openmetrics"
+	FormatTemplate    = "template" // set by --template
+)
+
+// Report bundles the analysis results rendered by the exporters
+type Report struct {
+	Analysis        *types.RepositoryAnalysis
+	Timeline        *analyzer.TimelineAnalysis // nil without a time breakdown
+	TopContributors int                        // people listed per vendor (0 omits the section)
+	GeneratedAt     time.Time
+	PinnedColors    map[string]string // vendor colors set in the config
+}
+
+// Vendors returns the vendors with commits, sorted by commits then name
+// with "others" last
+func (r *Report) Vendors() []*types.VendorMetrics {
+	return analyzer.RankVendors(r.Analysis.VendorMetrics, "commits")
+}
+
+// TimelineVendors returns the vendors active in any period, sorted by total
+// commits across periods then name with "others" last
+func (r *Report) TimelineVendors() []string {
+	if r.Timeline == nil {
+		return nil
+	}
+
+	totals := make(map[string]int)
+	for _, period := range r.Timeline.Periods {
+		for name, metrics := range period.VendorMetrics {
+			if metrics.TotalCommits > 0 {
+				totals[name] += metrics.TotalCommits
+			}
+		}
+	}
+	return analyzer.OthersLast(types.RankNames(totals))
+}
+
+// Colors returns the chart color of every vendor in the report
+func (r *Report) Colors() map[string]string {
+	names := make([]string, 0)
+	seen := make(map[string]bool)
+	for _, metrics := range r.Vendors() {
+		names = append(names, metrics.Name)
+		seen[metrics.Name] = true
+	}
+	for _, name := range r.TimelineVendors() {
+		if !seen[name] {
+			names = append(names, name)
+		}
+	}
+	return palette.Assign(names, r.PinnedColors)
+}
+
+// Percent returns value as a percentage of total
+func Percent(value, total int) float64 {
+	if total == 0 {
+		return 0
+	}
+	return float64(value) / float64(total) * 100
+}
+
+// totalAdditions sums lines added across vendors
+func (r *Report) totalAdditions() int {
+	total := 0
+	for _, metrics := range r.Analysis.VendorMetrics {
+		total += metrics.TotalAdditions
+	}
+	return total
+}
@@ -0,0 +1,127 @@
+package report
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/sderosiaux/git-contributor-insights/pkg/analyzer"
+	"github.com/sderosiaux/git-contributor-insights/pkg/chart"
+)
+
+// SVG file names written by WriteSVG
+const (
+	VendorShareSVG       = "vendor-share.svg"
+	TimelineSVG          = "timeline.svg"
+	ContributorsTrendSVG = "contributors-trend.svg"
+	CommunityBadgeSVG    = "community-badge.svg"
+)
+
+// WriteSVG writes standalone SVG charts into dir: the vendor share donut,
+// the community share badge and, with a timeline, the stacked commits area
+// chart and contributor trend lines. It returns the paths of the files written.
+func WriteSVG(dir string, r *Report) ([]string, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, err
+	}
+
+	files := []svgFile{
+		{VendorShareSVG, VendorShareChart},
+		{CommunityBadgeSVG, CommunityBadge},
+	}
+	if r.Timeline != nil && len(r.Timeline.Periods) > 0 {
+		files = append(files, svgFile{TimelineSVG, TimelineChart}, svgFile{ContributorsTrendSVG, ContributorsTrendChart})
+	}
+
+	paths := make([]string, 0, len(files))
+	for _, file := range files {
+		path := filepath.Join(dir, file.name)
+		err := writeFile(path, r, func(w io.Writer, r *Report) error {
+			_, err := io.WriteString(w, file.render(r))
+			return err
+		})
+		if err != nil {
+			return paths, err
+		}
+		paths = append(paths, path)
+	}
+	return paths, nil
+}
+
+// svgFile pairs an SVG file name with the function rendering it
+type svgFile struct {
+	name   string
+	render func(*Report) string
+}
+
+// VendorShareChart renders the commit share of each vendor as a donut
+func VendorShareChart(r *Report) string {
+	colors := r.Colors()
+	slices := make([]chart.Slice, 0)
+	for _, m := range r.Vendors() {
+		slices = append(slices, chart.Slice{Label: m.Name, Value: float64(m.TotalCommits), Color: colors[m.Name]})
+	}
+	title := fmt.Sprintf("%s: commits by vendor", r.Analysis.RepoName)
+	return chart.Donut(title, slices, 240, analyzer.FormatNumber(r.Analysis.TotalCommits))
+}
+
+// TimelineChart renders commits per period stacked by vendor
+func TimelineChart(r *Report) string {
+	labels, commits, _ := r.timelineSeries()
+	title := fmt.Sprintf("%s: commits per %s", r.Timeline.RepoName, r.Timeline.Breakdown)
+	return chart.StackedArea(title, labels, commits, 900, 340, false)
+}
+
+// ContributorsTrendChart renders one contributor-count line per vendor
+func ContributorsTrendChart(r *Report) string {
+	labels, _, contributors := r.timelineSeries()
+	title := fmt.Sprintf("%s: contributors per %s", r.Timeline.RepoName, r.Timeline.Breakdown)
+	return chart.Lines(title, labels, contributors, 900, 340)
+}
+
+// CommunityBadge renders a "community share: N%" badge, colored by how
+// much of the work comes from the community
+func CommunityBadge(r *Report) string {
+	share := 0.0
+	if community, ok := r.Analysis.VendorMetrics["community"]; ok {
+		share = Percent(community.TotalCommits, r.Analysis.TotalCommits)
+	}
+
+	color := "#e05d44" // red: vendor-dominated
+	switch {
+	case share >= 50:
+		color = "#44cc11"
+	case share >= 20:
+		color = "#dfb317"
+	}
+	return chart.Badge("community share", fmt.Sprintf("%.0f%%", share), color)
+}
+
+// timelineSeries returns the period labels and the per-vendor commit and
+// contributor series of the timeline
+func (r *Report) timelineSeries() ([]string, []chart.Series, []chart.Series) {
+	colors := r.Colors()
+	vendors := r.TimelineVendors()
+
+	labels := make([]string, 0, len(r.Timeline.Periods))
+	commits := make([]chart.Series, len(vendors))
+	contributors := make([]chart.Series, len(vendors))
+	for i, vendor := range vendors {
+		commits[i] = chart.Series{Label: vendor, Color: colors[vendor]}
+		contributors[i] = chart.Series{Label: vendor, Color: colors[vendor]}
+	}
+
+	for _, period := range r.Timeline.Periods {
+		labels = append(labels, period.Period)
+		for i, vendor := range vendors {
+			c, n := 0, 0
+			if m, ok := period.VendorMetrics[vendor]; ok {
+				c, n = m.TotalCommits, m.ContributorCount()
+			}
+			commits[i].Values = append(commits[i].Values, float64(c))
+			contributors[i].Values = append(contributors[i].Values, float64(n))
+		}
+	}
+	return labels, commits, contributors
+}
@@ -0,0 +1,65 @@
+package report
+
+import (
+	"fmt"
+	"io"
+	"path/filepath"
+	"reflect"
+	"text/template"
+
+	"github.com/sderosiaux/git-contributor-insights/pkg/analyzer"
+	"github.com/sderosiaux/git-contributor-insights/pkg/types"
+)
+
+// TemplateFuncs are the helpers available to --template files
+var TemplateFuncs = template.FuncMap{
+	"formatNumber": analyzer.FormatNumber,
+	"percent": func(value, total int) string {
+		return fmt.Sprintf("%.1f%%", Percent(value, total))
+	},
+	"sortVendors": SortVendors,
+	"topN":        topN,
+	"contributor": (*types.ContributorData).Identity,
+}
+
+// ParseTemplate reads a user-supplied text/template file with the report helpers
+func ParseTemplate(path string) (*template.Template, error) {
+	tmpl, err := template.New(filepath.Base(path)).Funcs(TemplateFuncs).ParseFiles(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse template: %w", err)
+	}
+	return tmpl, nil
+}
+
+// WriteTemplate executes a parsed template with the report as its data
+func WriteTemplate(w io.Writer, r *Report, tmpl *template.Template) error {
+	if err := tmpl.Execute(w, r); err != nil {
+		return fmt.Errorf("failed to execute template: %w", err)
+	}
+	return nil
+}
+
+// SortVendors returns the vendors of a metrics map with commits, sorted by
+// commits, additions, deletions or contributors (descending) or by name,
+// with "others" last
+func SortVendors(metrics map[string]*types.VendorMetrics, by string) ([]*types.VendorMetrics, error) {
+	switch by {
+	case "commits", "additions", "deletions", "contributors", "name", "":
+		return analyzer.RankVendors(metrics, by), nil
+	default:
+		return nil, fmt.Errorf("unknown sort key %q (must be: commits, additions, deletions, contributors, name)", by)
+	}
+}
+
+// topN returns the first n items of a slice, so it can end a pipeline:
+// {{range sortVendors .Analysis.VendorMetrics "commits" | topN 5}}
+func topN(n int, items interface{}) (interface{}, error) {
+	v := reflect.ValueOf(items)
+	if v.Kind() != reflect.Slice {
+		return nil, fmt.Errorf("topN expects a list, got %T", items)
+	}
+	if n >= 0 && v.Len() > n {
+		return v.Slice(0, n).Interface(), nil
+	}
+	return items, nil
+}
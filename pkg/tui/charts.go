@@ -0,0 +1,87 @@
+package tui
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// sparkLevels are the glyphs of a sparkline, lowest to highest
+var sparkLevels = []rune("▁▂▃▄▅▆▇█")
+
+// barSegment is one colored part of a stacked bar
+type barSegment struct {
+	value int
+	color lipgloss.Color
+}
+
+// stackedBar renders segments as adjacent colored blocks scaled so that total
+// fills width. Segments summing to less than total leave a dim remainder.
+func stackedBar(segments []barSegment, total, width int) string {
+	if total <= 0 || width <= 0 {
+		return ""
+	}
+
+	var bar strings.Builder
+	used, cumulative := 0, 0
+	for _, s := range segments {
+		cumulative += s.value
+		// Round the running total so segments always add up to the same width
+		end := int(float64(cumulative)/float64(total)*float64(width) + 0.5)
+		if end > width {
+			end = width
+		}
+		if end > used {
+			bar.WriteString(lipgloss.NewStyle().Foreground(s.color).Render(strings.Repeat("█", end-used)))
+			used = end
+		}
+	}
+	if used < width {
+		bar.WriteString(dimStyle.Render(strings.Repeat("░", width-used)))
+	}
+	return bar.String()
+}
+
+// sparkline renders values as a row of block glyphs scaled to the largest
+// value; zeros are blank. More values than width are averaged into buckets.
+func sparkline(values []int, width int) string {
+	values = resample(values, width)
+
+	max := 0
+	for _, v := range values {
+		if v > max {
+			max = v
+		}
+	}
+
+	var out strings.Builder
+	for _, v := range values {
+		if v == 0 || max == 0 {
+			out.WriteRune(' ')
+			continue
+		}
+		level := (v*len(sparkLevels) - 1) / max
+		out.WriteRune(sparkLevels[level])
+	}
+	return out.String()
+}
+
+// resample averages consecutive values so that at most width remain
+func resample(values []int, width int) []int {
+	if width <= 0 || len(values) <= width {
+		return values
+	}
+
+	result := make([]int, width)
+	for i := range result {
+		start := i * len(values) / width
+		end := (i + 1) * len(values) / width
+		sum := 0
+		for _, v := range values[start:end] {
+			sum += v
+		}
+		// Round up so a bucket with any activity never renders blank
+		result[i] = (sum + end - start - 1) / (end - start)
+	}
+	return result
+}
@@ -7,6 +7,7 @@ import (
 
 	"github.com/charmbracelet/lipgloss"
 	"github.com/sderosiaux/git-contributor-insights/pkg/analyzer"
+	"github.com/sderosiaux/git-contributor-insights/pkg/palette"
 	"github.com/sderosiaux/git-contributor-insights/pkg/types"
 )
 
@@ -41,40 +42,59 @@ var (
 			Padding(1, 2)
 )
 
+const (
+	maxNameWidth = 30     // vendor names longer than this are truncated
+	minNameWidth = 10     // narrowest vendor column on small terminals
+	childPrefix  = "  ↳ " // marks brands folded into a parent by a rollup
+	maxBarWidth  = 50     // widest bar chart
+	minBarWidth  = 10     // narrowest bar chart
+)
+
+// Options controls optional sections of the rendered analysis
+type Options struct {
+	// TopContributors lists the N most active people per vendor (0 disables the section)
+	TopContributors int
+	// Width is the terminal width to fit the output in (0 renders the full layout)
+	Width int
+	// Colors pins vendor colors ("#rrggbb"), usually from the config
+	Colors map[string]string
+}
+
 // Display renders the complete analysis to terminal
 type Display struct {
 	analysis *types.RepositoryAnalysis
 	colors   map[string]lipgloss.Color
+	options  Options
 }
 
 // New creates a new Display
 func New(analysis *types.RepositoryAnalysis) *Display {
+	return NewWithOptions(analysis, Options{})
+}
+
+// NewWithOptions creates a new Display with optional sections enabled
+func NewWithOptions(analysis *types.RepositoryAnalysis, options Options) *Display {
 	d := &Display{
 		analysis: analysis,
-		colors:   make(map[string]lipgloss.Color),
+		options:  options,
 	}
 	d.assignColors()
 	return d
 }
 
-// assignColors assigns colors to vendors
+// assignColors assigns palette colors to vendors in commit order
 func (d *Display) assignColors() {
-	colors := []lipgloss.Color{
-		colorRed, colorBlue, colorGreen, colorYellow,
-		colorMagenta, colorCyan,
-	}
-
-	// Community gets special color
-	d.colors["community"] = lipgloss.Color("7") // white
+	d.colors = vendorColors(analyzer.GetSortedVendors(d.analysis, "commits", true), d.options.Colors)
+}
 
-	// Assign colors to other vendors
-	i := 0
-	for vendor := range d.analysis.VendorMetrics {
-		if vendor != "community" {
-			d.colors[vendor] = colors[i%len(colors)]
-			i++
-		}
+// vendorColors maps vendors, ranked by the caller, to the palette shared
+// with the exported charts
+func vendorColors(names []string, pinned map[string]string) map[string]lipgloss.Color {
+	colors := make(map[string]lipgloss.Color, len(names))
+	for name, hex := range palette.Assign(names, pinned) {
+		colors[name] = lipgloss.Color(hex)
 	}
+	return colors
 }
 
 // Render renders the complete analysis
@@ -91,6 +111,10 @@ func (d *Display) Render() string {
 	out.WriteString("\n\n")
 	out.WriteString(d.renderBarChart("contributors"))
 	out.WriteString("\n\n")
+	if d.options.TopContributors > 0 {
+		out.WriteString(d.renderTopContributors(d.options.TopContributors))
+		out.WriteString("\n\n")
+	}
 	out.WriteString(d.renderInsights())
 
 	return out.String()
@@ -113,6 +137,88 @@ func (d *Display) renderHeader() string {
 	return boxStyle.Render(content)
 }
 
+// summaryColumn is a numeric column of the summary table
+type summaryColumn struct {
+	title string
+	width int
+	drop  int // columns with the highest drop rank go first on narrow terminals (0 never)
+	value func(m *types.VendorMetrics) string
+}
+
+// summaryColumns returns the numeric columns of the summary table
+func (d *Display) summaryColumns() []summaryColumn {
+	return []summaryColumn{
+		{"Commits", 10, 0, func(m *types.VendorMetrics) string {
+			return analyzer.FormatNumber(m.TotalCommits)
+		}},
+		{"% Commits", 10, 0, func(m *types.VendorMetrics) string {
+			return fmt.Sprintf("%.1f%%", d.calculatePercentage(m.TotalCommits, d.analysis.TotalCommits))
+		}},
+		{"Contributors", 14, 0, func(m *types.VendorMetrics) string {
+			return analyzer.FormatNumber(m.ContributorCount())
+		}},
+		{"% Contributors", 16, 3, func(m *types.VendorMetrics) string {
+			return fmt.Sprintf("%.1f%%", d.calculatePercentage(m.ContributorCount(), d.analysis.TotalContributors))
+		}},
+		{"Lines Added", 15, 1, func(m *types.VendorMetrics) string {
+			return "+" + analyzer.FormatNumber(m.TotalAdditions)
+		}},
+		{"Lines Deleted", 15, 2, func(m *types.VendorMetrics) string {
+			return "-" + analyzer.FormatNumber(m.TotalDeletions)
+		}},
+		{"Net Change", 13, 4, func(m *types.VendorMetrics) string {
+			return analyzer.FormatNumber(m.NetChanges())
+		}},
+	}
+}
+
+// summaryLayout picks the category column width and the numeric columns that
+// fit the terminal, dropping optional columns before truncating names
+func (d *Display) summaryLayout(names []string) (int, []summaryColumn) {
+	nameWidth := 18
+	for _, name := range names {
+		if n := lipgloss.Width(name); n > nameWidth {
+			nameWidth = n
+		}
+	}
+	if nameWidth > maxNameWidth {
+		nameWidth = maxNameWidth
+	}
+
+	columns := d.summaryColumns()
+	lineWidth := func() int {
+		w := nameWidth - 1
+		for _, c := range columns {
+			w += c.width + 2
+		}
+		return w
+	}
+	if d.options.Width <= 0 {
+		return nameWidth, columns
+	}
+
+	for lineWidth() > d.options.Width {
+		drop := -1
+		for i, c := range columns {
+			if c.drop > 0 && (drop < 0 || c.drop > columns[drop].drop) {
+				drop = i
+			}
+		}
+		if drop < 0 {
+			break
+		}
+		columns = append(columns[:drop:drop], columns[drop+1:]...)
+	}
+
+	if excess := lineWidth() - d.options.Width; excess > 0 {
+		nameWidth -= excess
+		if nameWidth < minNameWidth {
+			nameWidth = minNameWidth
+		}
+	}
+	return nameWidth, columns
+}
+
 // renderSummaryTable renders the vendor/community breakdown table
 func (d *Display) renderSummaryTable() string {
 	var out strings.Builder
@@ -120,70 +226,93 @@ func (d *Display) renderSummaryTable() string {
 	out.WriteString(headerStyle.Render("Vendor/Community Breakdown"))
 	out.WriteString("\n\n")
 
-	// Header with proper alignment
-	out.WriteString(fmt.Sprintf("%-18s %10s  %10s  %14s  %16s  %15s  %15s  %13s\n",
-		"Category", "Commits", "% Commits", "Contributors", "% Contributors",
-		"Lines Added", "Lines Deleted", "Net Change"))
-
-	out.WriteString(strings.Repeat("─", 132))
-	out.WriteString("\n")
-
 	// Show all vendors sorted by commits (hide vendors with 0 commits)
 	vendors := analyzer.GetSortedVendors(d.analysis, "commits", true)
 
-	// Check if we're in auto-classify mode (domains start with @)
-	isAutoClassify := d.isAutoClassifyMode(vendors)
-
-	// If auto-classify mode, limit to top 5 domains (plus community)
-	if isAutoClassify {
-		vendors = d.limitToTopDomains(vendors, 5)
-	}
-
-	groups := make([]*analyzer.VendorGroup, 0, len(vendors))
+	rows := make([]*types.VendorMetrics, 0, len(vendors))
+	names := make([]string, 0, len(vendors))
 	for _, vendor := range vendors {
 		metrics := d.analysis.VendorMetrics[vendor]
 		// Skip vendors with no commits
 		if metrics.TotalCommits == 0 {
 			continue
 		}
-		groups = append(groups, &analyzer.VendorGroup{
-			Name:               vendor,
-			TotalCommits:       metrics.TotalCommits,
-			TotalAdditions:     metrics.TotalAdditions,
-			TotalDeletions:     metrics.TotalDeletions,
-			UniqueContributors: metrics.UniqueContributors,
-			IsGrouped:          false,
-		})
-	}
-
-	for _, group := range groups {
-		commitsPct := d.calculatePercentage(group.TotalCommits, d.analysis.TotalCommits)
-		contributorsPct := d.calculatePercentage(group.ContributorCount(), d.analysis.TotalContributors)
-
-		// Format the row data first (no styling for alignment)
-		commitsStr := analyzer.FormatNumber(group.TotalCommits)
-		commitsPctStr := fmt.Sprintf("%.1f%%", commitsPct)
-		contributorsStr := analyzer.FormatNumber(group.ContributorCount())
-		contributorsPctStr := fmt.Sprintf("%.1f%%", contributorsPct)
-		additionsStr := "+" + analyzer.FormatNumber(group.TotalAdditions)
-		deletionsStr := "-" + analyzer.FormatNumber(group.TotalDeletions)
-		netChangeStr := analyzer.FormatNumber(group.NetChanges())
+		rows = append(rows, metrics)
+		names = append(names, vendor)
+		for _, child := range metrics.Children {
+			names = append(names, childPrefix+child.Name)
+		}
+	}
+
+	nameWidth, columns := d.summaryLayout(names)
+
+	// Header with proper alignment
+	header := fmt.Sprintf("%-*s", nameWidth, "Category")
+	lineWidth := nameWidth - 1
+	for i, c := range columns {
+		sep := "  "
+		if i == 0 {
+			sep = " "
+		}
+		header += fmt.Sprintf("%s%*s", sep, c.width, c.title)
+		lineWidth += c.width + 2
+	}
+	out.WriteString(header + "\n")
+	out.WriteString(strings.Repeat("─", lineWidth))
+	out.WriteString("\n")
 
+	for _, metrics := range rows {
 		// Style the vendor name after calculating padding
-		vendorStyle := lipgloss.NewStyle().Foreground(d.colors[group.Name])
-		paddedVendor := fmt.Sprintf("%-18s", group.Name)
-		styledVendor := vendorStyle.Render(paddedVendor)
-
-		out.WriteString(fmt.Sprintf("%s %10s  %10s  %14s  %16s  %15s  %15s  %13s\n",
-			styledVendor,
-			commitsStr,
-			commitsPctStr,
-			contributorsStr,
-			contributorsPctStr,
-			additionsStr,
-			deletionsStr,
-			netChangeStr,
-		))
+		vendorStyle := lipgloss.NewStyle().Foreground(d.colors[metrics.Name])
+		paddedVendor := fmt.Sprintf("%-*s", nameWidth, Truncate(metrics.Name, nameWidth))
+
+		out.WriteString(vendorStyle.Render(paddedVendor))
+		out.WriteString(d.summaryCells(metrics, columns))
+		out.WriteString("\n")
+
+		// Brands folded into this company by a rollup
+		if len(metrics.Children) > 0 {
+			out.WriteString(d.renderChildRows(metrics, nameWidth, columns))
+		}
+	}
+
+	return out.String()
+}
+
+// summaryCells formats the numeric columns of a summary row
+func (d *Display) summaryCells(m *types.VendorMetrics, columns []summaryColumn) string {
+	var out strings.Builder
+	for i, c := range columns {
+		sep := "  "
+		if i == 0 {
+			sep = " "
+		}
+		out.WriteString(fmt.Sprintf("%s%*s", sep, c.width, c.value(m)))
+	}
+	return out.String()
+}
+
+// renderChildRows renders the indented brand rows of a rolled-up vendor
+func (d *Display) renderChildRows(parent *types.VendorMetrics, nameWidth int, columns []summaryColumn) string {
+	var out strings.Builder
+
+	children := make([]*types.VendorMetrics, 0, len(parent.Children))
+	for _, child := range parent.Children {
+		if child.TotalCommits > 0 {
+			children = append(children, child)
+		}
+	}
+	sort.Slice(children, func(i, j int) bool {
+		if children[i].TotalCommits != children[j].TotalCommits {
+			return children[i].TotalCommits > children[j].TotalCommits
+		}
+		return children[i].Name < children[j].Name
+	})
+
+	for _, child := range children {
+		row := fmt.Sprintf("%-*s", nameWidth, Truncate(childPrefix+child.Name, nameWidth)) + d.summaryCells(child, columns)
+		out.WriteString(dimStyle.Render(row))
+		out.WriteString("\n")
 	}
 
 	return out.String()
@@ -212,14 +341,6 @@ func (d *Display) renderBarChart(metric string) string {
 
 	vendors := analyzer.GetSortedVendors(d.analysis, metric, true)
 
-	// Check if we're in auto-classify mode (domains start with @)
-	isAutoClassify := d.isAutoClassifyMode(vendors)
-
-	// If auto-classify mode, limit to top 5 domains (plus community)
-	if isAutoClassify {
-		vendors = d.limitToTopDomains(vendors, 5)
-	}
-
 	// Get values and max
 	values := make([]int, len(vendors))
 	maxValue := 0
@@ -243,8 +364,33 @@ func (d *Display) renderBarChart(metric string) string {
 		}
 	}
 
+	// Labels are dot-padded to a common width; long names are truncated
+	labelWidth := 20
+	for _, vendor := range vendors {
+		if n := lipgloss.Width(vendor) + 1; n > labelWidth {
+			labelWidth = n
+		}
+	}
+	if labelWidth > maxNameWidth {
+		labelWidth = maxNameWidth
+	}
+
+	// Fit label, bar and the right-aligned value in the terminal
+	chartWidth := maxBarWidth
+	if d.options.Width > 0 {
+		if fit := d.options.Width - labelWidth - 10; fit < chartWidth {
+			chartWidth = fit
+		}
+		if chartWidth < minBarWidth {
+			chartWidth = minBarWidth
+			labelWidth = d.options.Width - chartWidth - 10
+			if labelWidth < minNameWidth {
+				labelWidth = minNameWidth
+			}
+		}
+	}
+
 	// Render bars
-	chartWidth := 50
 	for i, vendor := range vendors {
 		value := values[i]
 
@@ -258,8 +404,11 @@ func (d *Display) renderBarChart(metric string) string {
 		vendorStyle := lipgloss.NewStyle().Foreground(d.colors[vendor])
 		bar := strings.Repeat("█", barLength)
 
-		out.WriteString(fmt.Sprintf("%-20s %s %8s\n",
-			vendor+"...............",
+		name := Truncate(vendor, labelWidth-1)
+		label := name + strings.Repeat(".", labelWidth-lipgloss.Width(name))
+
+		out.WriteString(fmt.Sprintf("%s %s %8s\n",
+			label,
 			vendorStyle.Render(bar),
 			analyzer.FormatNumber(value),
 		))
@@ -268,6 +417,67 @@ func (d *Display) renderBarChart(metric string) string {
 	return out.String()
 }
 
+// renderTopContributors lists the most active people within each vendor
+func (d *Display) renderTopContributors(limit int) string {
+	var out strings.Builder
+
+	out.WriteString(headerStyle.Render("Top Contributors"))
+	out.WriteString("\n")
+
+	vendors := analyzer.GetSortedVendors(d.analysis, "commits", true)
+
+	// The identity column gives way on narrow terminals (the rest is 64 wide)
+	identityWidth := 44
+	if d.options.Width > 0 && identityWidth+64 > d.options.Width {
+		identityWidth = d.options.Width - 64
+		if identityWidth < 20 {
+			identityWidth = 20
+		}
+	}
+
+	for _, vendor := range vendors {
+		metrics := d.analysis.VendorMetrics[vendor]
+		if metrics.TotalCommits == 0 {
+			continue
+		}
+
+		vendorStyle := lipgloss.NewStyle().Foreground(d.colors[vendor]).Bold(true)
+		out.WriteString("\n")
+		out.WriteString(vendorStyle.Render(vendor))
+		out.WriteString(dimStyle.Render(fmt.Sprintf(" (%s contributors)", analyzer.FormatNumber(metrics.ContributorCount()))))
+		out.WriteString("\n")
+
+		out.WriteString(fmt.Sprintf("  %-*s %8s  %12s  %13s  %-10s  %s\n",
+			identityWidth, "Contributor", "Commits", "Lines Added", "Lines Deleted", "First", "Last"))
+
+		for _, c := range metrics.TopContributors(limit) {
+			out.WriteString(fmt.Sprintf("  %-*s %8s  %12s  %13s  %-10s  %s\n",
+				identityWidth,
+				Truncate(c.Identity(), identityWidth),
+				analyzer.FormatNumber(c.Commits),
+				"+"+analyzer.FormatNumber(c.Additions),
+				"-"+analyzer.FormatNumber(c.Deletions),
+				c.FirstCommit.Format("2006-01-02"),
+				c.LastCommit.Format("2006-01-02"),
+			))
+		}
+	}
+
+	return out.String()
+}
+
+// Truncate shortens s to at most width runes, marking the cut with an ellipsis
+func Truncate(s string, width int) string {
+	runes := []rune(s)
+	if len(runes) <= width {
+		return s
+	}
+	if width <= 1 {
+		return string(runes[:width])
+	}
+	return string(runes[:width-1]) + "…"
+}
+
 // renderInsights renders key insights
 func (d *Display) renderInsights() string {
 	var out strings.Builder
@@ -277,14 +487,6 @@ func (d *Display) renderInsights() string {
 
 	// Top contributor by commits
 	vendors := analyzer.GetSortedVendors(d.analysis, "commits", true)
-
-	// Check if we're in auto-classify mode (domains start with @)
-	isAutoClassify := d.isAutoClassifyMode(vendors)
-
-	// If auto-classify mode, limit to top 5 domains (plus community)
-	if isAutoClassify {
-		vendors = d.limitToTopDomains(vendors, 5)
-	}
 	if len(vendors) > 0 {
 		topVendor := vendors[0]
 		topMetrics := d.analysis.VendorMetrics[topVendor]
@@ -324,44 +526,6 @@ func (d *Display) renderInsights() string {
 	return out.String()
 }
 
-// isAutoClassifyMode checks if we're in auto-classify mode (no vendor file)
-// by checking if any vendor names start with "@" (which indicates email domains)
-func (d *Display) isAutoClassifyMode(vendors []string) bool {
-	for _, vendor := range vendors {
-		if strings.HasPrefix(vendor, "@") {
-			return true
-		}
-	}
-	return false
-}
-
-// limitToTopDomains limits vendors to top N domains plus community
-func (d *Display) limitToTopDomains(vendors []string, limit int) []string {
-	result := make([]string, 0, limit+1)
-	domainCount := 0
-
-	for _, vendor := range vendors {
-		// Always include community
-		if vendor == "community" {
-			result = append(result, vendor)
-			continue
-		}
-
-		// Include domains (prefixed with @) up to the limit
-		if strings.HasPrefix(vendor, "@") {
-			if domainCount < limit {
-				result = append(result, vendor)
-				domainCount++
-			}
-		} else {
-			// Include non-domain vendors (shouldn't happen in auto-classify mode)
-			result = append(result, vendor)
-		}
-	}
-
-	return result
-}
-
 // GetMonthsSorted returns sorted list of months from timeline
 func GetMonthsSorted(timeline map[string]map[string]int) []string {
 	months := make([]string, 0, len(timeline))
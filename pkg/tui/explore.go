@@ -0,0 +1,553 @@
+package tui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/sderosiaux/git-contributor-insights/pkg/analyzer"
+	"github.com/sderosiaux/git-contributor-insights/pkg/config"
+	"github.com/sderosiaux/git-contributor-insights/pkg/types"
+)
+
+// exploreView is a screen of the explorer
+type exploreView int
+
+const (
+	viewSummary      exploreView = iota // vendors of the whole range
+	viewTimeline                        // periods of the current breakdown
+	viewContributors                    // people of one vendor
+	viewCommits                         // commits of one period
+)
+
+var (
+	exploreMetrics    = []string{"commits", "additions", "contributors"}
+	exploreBreakdowns = []string{"year", "quarter", "month", "week"}
+
+	cursorStyle = lipgloss.NewStyle().Bold(true).Foreground(colorCyan)
+	tabStyle    = lipgloss.NewStyle().Padding(0, 1)
+	activeTab   = tabStyle.Bold(true).Foreground(lipgloss.Color("0")).Background(colorCyan)
+)
+
+// exploreState is a position to return to when leaving a drill-down
+type exploreState struct {
+	view   exploreView
+	cursor int
+	offset int
+}
+
+// Explorer is the interactive Bubble Tea model behind "ghca explore".
+// Commits are fetched and classified once; switching views, breakdowns and
+// metrics only recomputes from memory.
+type Explorer struct {
+	commits  []*types.CommitData
+	vendors  []string // classification of each commit, parallel to commits
+	cfg      *config.Config
+	repoName string
+
+	analysis  *types.RepositoryAnalysis
+	timelines map[string]*analyzer.TimelineAnalysis // computed on first use
+	colors    map[string]lipgloss.Color
+
+	view      exploreView
+	metric    int // index in exploreMetrics
+	breakdown int // index in exploreBreakdowns
+	cursor    int
+	offset    int
+	stack     []exploreState
+	vendor    string                  // vendor shown by viewContributors
+	period    *analyzer.TimeBreakdown // period shown by viewCommits
+
+	width  int
+	height int
+}
+
+// NewExplorer creates the explorer model, starting on the summary view
+func NewExplorer(commits []*types.CommitData, cfg *config.Config, repoName string, breakdown string) *Explorer {
+	e := &Explorer{
+		commits:   commits,
+		vendors:   make([]string, len(commits)),
+		cfg:       cfg,
+		repoName:  repoName,
+		analysis:  analyzer.New(cfg).Analyze(commits, nil, repoName),
+		timelines: make(map[string]*analyzer.TimelineAnalysis),
+	}
+
+	for i, commit := range commits {
+		e.vendors[i] = cfg.ClassifyContributor(config.Contributor{
+			Name:    commit.AuthorName,
+			Email:   commit.AuthorEmail,
+			Company: commit.AuthorCompany,
+			Date:    commit.Date,
+		})
+	}
+
+	for i, b := range exploreBreakdowns {
+		if b == breakdown {
+			e.breakdown = i
+		}
+	}
+
+	// Colors follow the overall commit ranking so they stay stable across views
+	names := make([]string, 0, len(e.analysis.VendorMetrics))
+	for _, m := range analyzer.RankVendors(e.analysis.VendorMetrics, "commits") {
+		names = append(names, m.Name)
+	}
+	e.colors = vendorColors(names, cfg.VendorColors())
+
+	return e
+}
+
+// Init implements tea.Model
+func (e *Explorer) Init() tea.Cmd {
+	return nil
+}
+
+// Update implements tea.Model
+func (e *Explorer) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		e.width, e.height = msg.Width, msg.Height
+
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "ctrl+c", "q":
+			return e, tea.Quit
+		case "up", "k":
+			e.cursor--
+		case "down", "j":
+			e.cursor++
+		case "pgup":
+			e.cursor -= e.bodyHeight()
+		case "pgdown":
+			e.cursor += e.bodyHeight()
+		case "home", "g":
+			e.cursor = 0
+		case "end", "G":
+			e.cursor = e.rowCount() - 1
+		case "tab":
+			if e.rootView() == viewSummary {
+				e.switchTo(viewTimeline)
+			} else {
+				e.switchTo(viewSummary)
+			}
+		case "s":
+			e.switchTo(viewSummary)
+		case "t":
+			e.switchTo(viewTimeline)
+		case "m":
+			e.metric = (e.metric + 1) % len(exploreMetrics)
+		case "b":
+			e.breakdown = (e.breakdown + 1) % len(exploreBreakdowns)
+			// A drilled-down period does not exist in the new breakdown
+			if e.view == viewCommits {
+				e.back()
+			}
+			e.cursor, e.offset = 0, 0
+		case "enter", "right", "l":
+			e.drillDown()
+		case "esc", "backspace", "left", "h":
+			e.back()
+		}
+	}
+
+	e.clampCursor()
+	return e, nil
+}
+
+// View implements tea.Model
+func (e *Explorer) View() string {
+	var out strings.Builder
+
+	out.WriteString(e.renderTabs())
+	out.WriteString("\n")
+	out.WriteString(dimStyle.Render(e.breadcrumb()))
+	out.WriteString("\n\n")
+
+	header, rows := e.renderRows()
+	out.WriteString(headerStyle.Render(header))
+	out.WriteString("\n")
+
+	end := e.offset + e.bodyHeight()
+	if end > len(rows) {
+		end = len(rows)
+	}
+	for i := e.offset; i < end; i++ {
+		marker := "  "
+		if i == e.cursor {
+			marker = cursorStyle.Render("▸ ")
+		}
+		out.WriteString(marker + rows[i] + "\n")
+	}
+	if len(rows) == 0 {
+		out.WriteString(dimStyle.Render("  nothing to show") + "\n")
+	}
+
+	out.WriteString("\n")
+	if e.view == viewTimeline {
+		out.WriteString(e.legend())
+	}
+	out.WriteString("\n")
+	out.WriteString(dimStyle.Render(e.helpLine()))
+	return out.String()
+}
+
+// legend maps the stacked bar colors to vendors, in overall commit order
+func (e *Explorer) legend() string {
+	parts := make([]string, 0, len(e.colors))
+	for _, m := range analyzer.RankVendors(e.analysis.VendorMetrics, "commits") {
+		parts = append(parts, lipgloss.NewStyle().Foreground(e.colors[m.Name]).Render("█ "+m.Name))
+	}
+	return "  " + ansi.Truncate(strings.Join(parts, "  "), e.contentWidth()-2, "…")
+}
+
+// renderTabs renders the view switcher with the active metric and breakdown
+func (e *Explorer) renderTabs() string {
+	tabs := []struct {
+		label string
+		view  exploreView
+	}{{"Summary", viewSummary}, {"Timeline", viewTimeline}}
+
+	parts := []string{titleStyle.UnsetMarginBottom().Render(e.repoName) + " "}
+	for _, tab := range tabs {
+		style := tabStyle
+		if e.rootView() == tab.view {
+			style = activeTab
+		}
+		parts = append(parts, style.Render(tab.label))
+	}
+	parts = append(parts, dimStyle.Render(fmt.Sprintf("  metric: %s  breakdown: %s  %s commits",
+		e.metricName(), exploreBreakdowns[e.breakdown], analyzer.FormatNumber(len(e.commits)))))
+
+	return lipgloss.JoinHorizontal(lipgloss.Top, parts...)
+}
+
+// breadcrumb describes the current drill-down path
+func (e *Explorer) breadcrumb() string {
+	switch e.view {
+	case viewContributors:
+		return "Summary › " + e.vendor + " › contributors"
+	case viewCommits:
+		return "Timeline › " + e.period.Period + " › commits"
+	case viewTimeline:
+		return fmt.Sprintf("Timeline by %s (share of %s per period)", exploreBreakdowns[e.breakdown], e.metricName())
+	default:
+		return fmt.Sprintf("Vendors by %s, %s to %s", e.metricName(),
+			e.analysis.DateRange.Start.Format("2006-01-02"), e.analysis.DateRange.End.Format("2006-01-02"))
+	}
+}
+
+// helpLine lists the keys available in the current view
+func (e *Explorer) helpLine() string {
+	keys := []string{"↑/↓ move", "tab view", "m metric", "b breakdown"}
+	switch e.view {
+	case viewSummary:
+		keys = append(keys, "enter contributors")
+	case viewTimeline:
+		keys = append(keys, "enter commits")
+	default:
+		keys = append(keys, "esc back")
+	}
+	return strings.Join(append(keys, "q quit"), " • ")
+}
+
+// renderRows returns the column header and the rows of the current view
+func (e *Explorer) renderRows() (string, []string) {
+	switch e.view {
+	case viewTimeline:
+		return e.timelineRows()
+	case viewContributors:
+		return e.contributorRows()
+	case viewCommits:
+		return e.commitRows()
+	default:
+		return e.summaryRows()
+	}
+}
+
+// summaryRows renders one row per vendor with its share of the metric
+func (e *Explorer) summaryRows() (string, []string) {
+	vendors := analyzer.RankVendors(e.analysis.VendorMetrics, e.metricName())
+	total := 0
+	for _, m := range vendors {
+		total += e.metricValue(m)
+	}
+
+	header := fmt.Sprintf("  %-24s %12s %7s", "Category", e.metricName(), "share")
+	barWidth := max(e.contentWidth()-50, 0)
+	rows := make([]string, 0, len(vendors))
+	for _, m := range vendors {
+		value := e.metricValue(m)
+		share := percentage(value, total)
+		bar := lipgloss.NewStyle().Foreground(e.colors[m.Name]).
+			Render(strings.Repeat("█", int(share/100*float64(barWidth)+0.5)))
+		rows = append(rows, fmt.Sprintf("%s %12s %6.1f%%  %s",
+			lipgloss.NewStyle().Foreground(e.colors[m.Name]).Render(fmt.Sprintf("%-24s", Truncate(m.Name, 24))),
+			analyzer.FormatNumber(value), share, bar))
+	}
+	return header, rows
+}
+
+// timelineRows renders one row per period with a stacked bar of vendor shares
+func (e *Explorer) timelineRows() (string, []string) {
+	timeline := e.timeline()
+	header := fmt.Sprintf("  %-10s %12s  %s", "Period", e.metricName(), "share by vendor")
+	barWidth := e.contentWidth() - 28
+
+	rows := make([]string, 0, len(timeline.Periods))
+	for _, period := range timeline.Periods {
+		vendors := analyzer.RankVendors(period.VendorMetrics, e.metricName())
+		segments := make([]barSegment, len(vendors))
+		total := 0
+		for i, m := range vendors {
+			segments[i] = barSegment{value: e.metricValue(m), color: e.colors[m.Name]}
+			total += segments[i].value
+		}
+
+		// Contributors are counted once per period, not summed across vendors
+		shown := total
+		if e.metricName() == "contributors" {
+			shown = periodContributors(period)
+		}
+
+		rows = append(rows, fmt.Sprintf("%-10s %12s  %s",
+			period.Period, analyzer.FormatNumber(shown), stackedBar(segments, total, barWidth)))
+	}
+	return header, rows
+}
+
+// contributorRows renders the people of the drilled-down vendor
+func (e *Explorer) contributorRows() (string, []string) {
+	metrics := e.analysis.VendorMetrics[e.vendor]
+	if metrics == nil {
+		return "", nil
+	}
+
+	people := types.SortContributors(metrics.UniqueContributors, 0)
+	if e.metricName() == "additions" {
+		sort.SliceStable(people, func(i, j int) bool { return people[i].Additions > people[j].Additions })
+	}
+
+	header := fmt.Sprintf("  %-44s %8s %10s %10s  %-10s  %-10s", "Contributor", "Commits", "Added", "Deleted", "First", "Last")
+	rows := make([]string, 0, len(people))
+	for _, c := range people {
+		rows = append(rows, fmt.Sprintf("%-44s %8s %10s %10s  %-10s  %-10s",
+			Truncate(c.Identity(), 44),
+			analyzer.FormatNumber(c.Commits),
+			"+"+analyzer.FormatNumber(c.Additions),
+			"-"+analyzer.FormatNumber(c.Deletions),
+			c.FirstCommit.Format("2006-01-02"),
+			c.LastCommit.Format("2006-01-02")))
+	}
+	return header, rows
+}
+
+// commitRows renders the commits of the drilled-down period, newest first
+func (e *Explorer) commitRows() (string, []string) {
+	indexes := e.periodCommits()
+
+	header := fmt.Sprintf("  %-10s  %-8s  %-18s %-28s %8s %8s  %s", "Date", "SHA", "Category", "Author", "Added", "Deleted", "Message")
+	messageWidth := e.contentWidth() - 94
+	if messageWidth < 10 {
+		messageWidth = 10
+	}
+
+	rows := make([]string, 0, len(indexes))
+	for _, i := range indexes {
+		commit := e.commits[i]
+		vendor := e.vendors[i]
+		message := strings.SplitN(strings.TrimSpace(commit.Message), "\n", 2)[0]
+		sha := commit.SHA
+		if len(sha) > 8 {
+			sha = sha[:8]
+		}
+		rows = append(rows, fmt.Sprintf("%-10s  %-8s  %s %-28s %8s %8s  %s",
+			commit.Date.Format("2006-01-02"),
+			sha,
+			lipgloss.NewStyle().Foreground(e.colors[vendor]).Render(fmt.Sprintf("%-18s", Truncate(vendor, 18))),
+			Truncate(commit.AuthorName, 28),
+			"+"+analyzer.FormatNumber(commit.Additions),
+			"-"+analyzer.FormatNumber(commit.Deletions),
+			Truncate(message, messageWidth)))
+	}
+	return header, rows
+}
+
+// drillDown opens the contributors of a vendor or the commits of a period
+func (e *Explorer) drillDown() {
+	switch e.view {
+	case viewSummary:
+		vendors := analyzer.RankVendors(e.analysis.VendorMetrics, e.metricName())
+		if e.cursor >= len(vendors) {
+			return
+		}
+		e.push()
+		e.vendor = vendors[e.cursor].Name
+		e.view = viewContributors
+	case viewTimeline:
+		periods := e.timeline().Periods
+		if e.cursor >= len(periods) {
+			return
+		}
+		e.push()
+		e.period = periods[e.cursor]
+		e.view = viewCommits
+	default:
+		return
+	}
+	e.cursor, e.offset = 0, 0
+}
+
+// push saves the current position for back
+func (e *Explorer) push() {
+	e.stack = append(e.stack, exploreState{view: e.view, cursor: e.cursor, offset: e.offset})
+}
+
+// back returns to the view a drill-down started from
+func (e *Explorer) back() {
+	if len(e.stack) == 0 {
+		return
+	}
+	last := e.stack[len(e.stack)-1]
+	e.stack = e.stack[:len(e.stack)-1]
+	e.view, e.cursor, e.offset = last.view, last.cursor, last.offset
+}
+
+// switchTo jumps to a top-level view, dropping any drill-down
+func (e *Explorer) switchTo(view exploreView) {
+	if e.view == view {
+		return
+	}
+	e.view = view
+	e.stack = nil
+	e.cursor, e.offset = 0, 0
+}
+
+// rootView returns the top-level view the current screen belongs to
+func (e *Explorer) rootView() exploreView {
+	switch e.view {
+	case viewContributors:
+		return viewSummary
+	case viewCommits:
+		return viewTimeline
+	default:
+		return e.view
+	}
+}
+
+// clampCursor keeps the cursor on a row and scrolls it into view
+func (e *Explorer) clampCursor() {
+	count := e.rowCount()
+	if e.cursor >= count {
+		e.cursor = count - 1
+	}
+	if e.cursor < 0 {
+		e.cursor = 0
+	}
+
+	height := e.bodyHeight()
+	if e.cursor < e.offset {
+		e.offset = e.cursor
+	}
+	if e.cursor >= e.offset+height {
+		e.offset = e.cursor - height + 1
+	}
+}
+
+// rowCount returns the number of rows in the current view
+func (e *Explorer) rowCount() int {
+	switch e.view {
+	case viewTimeline:
+		return len(e.timeline().Periods)
+	case viewContributors:
+		if metrics := e.analysis.VendorMetrics[e.vendor]; metrics != nil {
+			return metrics.ContributorCount()
+		}
+		return 0
+	case viewCommits:
+		return len(e.periodCommits())
+	default:
+		return len(analyzer.RankVendors(e.analysis.VendorMetrics, e.metricName()))
+	}
+}
+
+// bodyHeight returns how many rows fit between the header and the help line
+func (e *Explorer) bodyHeight() int {
+	if e.height == 0 {
+		return 20
+	}
+	if h := e.height - 7; h > 1 {
+		return h
+	}
+	return 1
+}
+
+// contentWidth returns the usable terminal width
+func (e *Explorer) contentWidth() int {
+	if e.width == 0 {
+		return 100
+	}
+	return e.width - 2
+}
+
+// timeline returns the timeline of the current breakdown, computing it once
+func (e *Explorer) timeline() *analyzer.TimelineAnalysis {
+	breakdown := exploreBreakdowns[e.breakdown]
+	timeline, ok := e.timelines[breakdown]
+	if !ok {
+		timeline = analyzer.AnalyzeTimeline(e.commits, e.cfg, e.repoName, breakdown)
+		e.timelines[breakdown] = timeline
+	}
+	return timeline
+}
+
+// periodCommits returns the indexes of the commits in the drilled-down period, newest first
+func (e *Explorer) periodCommits() []int {
+	if e.period == nil {
+		return nil
+	}
+
+	breakdown := exploreBreakdowns[e.breakdown]
+	indexes := make([]int, 0)
+	for i, commit := range e.commits {
+		if analyzer.PeriodKey(commit.Date, breakdown) == e.period.Period {
+			indexes = append(indexes, i)
+		}
+	}
+	sort.SliceStable(indexes, func(a, b int) bool {
+		return e.commits[indexes[a]].Date.After(e.commits[indexes[b]].Date)
+	})
+	return indexes
+}
+
+// metricName returns the metric currently shown
+func (e *Explorer) metricName() string {
+	return exploreMetrics[e.metric]
+}
+
+// metricValue returns a vendor's value for the current metric
+func (e *Explorer) metricValue(m *types.VendorMetrics) int {
+	return m.Metric(e.metricName())
+}
+
+// periodContributors counts the unique contributors of a period across vendors
+func periodContributors(period *analyzer.TimeBreakdown) int {
+	seen := make(map[string]bool)
+	for _, m := range period.VendorMetrics {
+		for id := range m.UniqueContributors {
+			seen[id] = true
+		}
+	}
+	return len(seen)
+}
+
+// percentage returns value as a percentage of total
+func percentage(value, total int) float64 {
+	if total == 0 {
+		return 0
+	}
+	return float64(value) / float64(total) * 100
+}
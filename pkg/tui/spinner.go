@@ -3,10 +3,15 @@ package tui
 import (
 	"fmt"
 	"io"
+	"os"
 	"sync"
 	"time"
+
+	"github.com/charmbracelet/lipgloss"
 )
 
+var spinnerStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("6"))
+
 // Spinner provides animated feedback during long operations
 type Spinner struct {
 	frames   []string
@@ -28,7 +33,8 @@ func NewSpinner(writer io.Writer, message string) *Spinner {
 	}
 }
 
-// Start begins the spinner animation
+// Start begins the spinner animation. When the writer is not a terminal
+// (CI logs, pipes) the message is printed once instead, without escape codes.
 func (s *Spinner) Start() {
 	s.mu.Lock()
 	defer s.mu.Unlock()
@@ -37,6 +43,11 @@ func (s *Spinner) Start() {
 		return
 	}
 
+	if f, ok := s.writer.(*os.File); !ok || !IsTerminal(f) {
+		fmt.Fprintln(s.writer, s.message)
+		return
+	}
+
 	s.wg.Add(1)
 	go func() {
 		defer s.wg.Done()
@@ -55,9 +66,7 @@ func (s *Spinner) Start() {
 			case <-ticker.C:
 				// Update spinner frame
 				frame := s.frames[frameIdx%len(s.frames)]
-				cyan := "\033[36m"
-				reset := "\033[0m"
-				fmt.Fprintf(s.writer, "\r%s%s%s %s", cyan, frame, reset, s.message)
+				fmt.Fprintf(s.writer, "\r%s %s", spinnerStyle.Render(frame), s.message)
 				frameIdx++
 			}
 		}
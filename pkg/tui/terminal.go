@@ -0,0 +1,59 @@
+package tui
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/term"
+	"github.com/muesli/termenv"
+)
+
+// Color modes accepted by --color
+const (
+	ColorAuto   = "auto"
+	ColorAlways = "always"
+	ColorNever  = "never"
+)
+
+// SetColorMode configures colors for everything rendered with lipgloss:
+// "always" forces ANSI colors, "never" disables all styling and "auto"
+// enables colors only when out is a terminal and NO_COLOR is not set
+func SetColorMode(mode string, out *os.File) error {
+	switch mode {
+	case ColorAlways:
+		lipgloss.SetColorProfile(termenv.ANSI256)
+	case ColorNever:
+		lipgloss.SetColorProfile(termenv.Ascii)
+	case ColorAuto, "":
+		if os.Getenv("NO_COLOR") != "" || !IsTerminal(out) {
+			lipgloss.SetColorProfile(termenv.Ascii)
+		} else {
+			lipgloss.SetColorProfile(termenv.NewOutput(out).ColorProfile())
+		}
+	default:
+		return fmt.Errorf("invalid color mode %q (must be: auto, always, never)", mode)
+	}
+	return nil
+}
+
+// IsTerminal reports whether f is an interactive terminal
+func IsTerminal(f *os.File) bool {
+	return f != nil && term.IsTerminal(f.Fd())
+}
+
+// TerminalWidth returns the width of the terminal behind f, falling back
+// to $COLUMNS. It returns 0 (no limit) when neither is known, e.g. when the
+// output is piped to a file.
+func TerminalWidth(f *os.File) int {
+	if IsTerminal(f) {
+		if width, _, err := term.GetSize(f.Fd()); err == nil && width > 0 {
+			return width
+		}
+	}
+	if columns, err := strconv.Atoi(os.Getenv("COLUMNS")); err == nil && columns > 0 {
+		return columns
+	}
+	return 0
+}
@@ -2,54 +2,45 @@ package tui
 
 import (
 	"fmt"
-	"sort"
 	"strings"
 
 	"github.com/charmbracelet/lipgloss"
 	"github.com/sderosiaux/git-contributor-insights/pkg/analyzer"
+	"github.com/sderosiaux/git-contributor-insights/pkg/types"
+)
+
+const (
+	shareBarWidth  = 60 // width of the per-period stacked share bars
+	sparklineWidth = 60 // periods beyond this are averaged into buckets
+
+	minSparklineWidth = 8 // narrowest sparkline on small terminals
 )
 
 // TimelineDisplay renders timeline analysis
 type TimelineDisplay struct {
 	timeline *analyzer.TimelineAnalysis
 	colors   map[string]lipgloss.Color
+	options  Options
 }
 
 // NewTimeline creates a new TimelineDisplay
 func NewTimeline(timeline *analyzer.TimelineAnalysis) *TimelineDisplay {
+	return NewTimelineWithOptions(timeline, Options{})
+}
+
+// NewTimelineWithOptions creates a new TimelineDisplay fitted to options.Width
+func NewTimelineWithOptions(timeline *analyzer.TimelineAnalysis, options Options) *TimelineDisplay {
 	d := &TimelineDisplay{
 		timeline: timeline,
-		colors:   make(map[string]lipgloss.Color),
+		options:  options,
 	}
 	d.assignColors()
 	return d
 }
 
-// assignColors assigns colors to vendors
+// assignColors assigns palette colors to vendors in total commit order
 func (d *TimelineDisplay) assignColors() {
-	colors := []lipgloss.Color{
-		colorRed, colorBlue, colorGreen, colorYellow,
-		colorMagenta, colorCyan,
-	}
-
-	// Community gets special color
-	d.colors["community"] = lipgloss.Color("7") // white
-
-	// Assign colors to other vendors (collect all vendors across all periods)
-	vendorSet := make(map[string]bool)
-	for _, period := range d.timeline.Periods {
-		for vendor := range period.VendorMetrics {
-			if vendor != "community" {
-				vendorSet[vendor] = true
-			}
-		}
-	}
-
-	i := 0
-	for vendor := range vendorSet {
-		d.colors[vendor] = colors[i%len(colors)]
-		i++
-	}
+	d.colors = vendorColors(d.getVendorsToDisplay(d.vendorSet()), d.options.Colors)
 }
 
 // Render renders the timeline analysis
@@ -60,6 +51,10 @@ func (d *TimelineDisplay) Render() string {
 	out.WriteString("\n\n")
 	out.WriteString(d.renderTimelineTable())
 	out.WriteString("\n\n")
+	out.WriteString(d.renderShareBars())
+	out.WriteString("\n\n")
+	out.WriteString(d.renderSparklines())
+	out.WriteString("\n\n")
 	out.WriteString(d.renderTrendSummary())
 
 	return out.String()
@@ -89,71 +84,278 @@ func (d *TimelineDisplay) renderHeader() string {
 	return boxStyle.Render(content)
 }
 
-// renderTimelineTable renders the period-by-period breakdown
+// renderTimelineTable renders the period-by-period breakdown. Vendor columns
+// are sized to their content; when they do not fit the terminal the cells
+// fall back to shares only, then trailing vendors are left out.
 func (d *TimelineDisplay) renderTimelineTable() string {
 	var out strings.Builder
 
 	out.WriteString(headerStyle.Render("Timeline Breakdown"))
 	out.WriteString("\n\n")
 
-	// Get all unique vendors across all periods
-	vendorSet := make(map[string]bool)
-	for _, period := range d.timeline.Periods {
-		for vendor := range period.VendorMetrics {
-			vendorSet[vendor] = true
+	vendors := d.displayVendors()
+	periodWidth := d.periodWidth()
+
+	// Cells in full ("1,234 (45%)") and compact ("45%") form
+	full := make(map[string][]string, len(vendors))
+	compact := make(map[string][]string, len(vendors))
+	for _, vendor := range vendors {
+		for _, period := range d.timeline.Periods {
+			metrics, ok := period.VendorMetrics[vendor]
+			if !ok || metrics.TotalCommits == 0 {
+				full[vendor] = append(full[vendor], "-")
+				compact[vendor] = append(compact[vendor], "-")
+				continue
+			}
+
+			pct := period.GetVendorPercentage(vendor, "commits")
+			full[vendor] = append(full[vendor], fmt.Sprintf("%s (%.0f%%)", analyzer.FormatNumber(metrics.TotalCommits), pct))
+			compact[vendor] = append(compact[vendor], fmt.Sprintf("%.0f%%", pct))
 		}
 	}
 
-	// Show all vendors (sorted by total commits)
-	vendors := d.getVendorsToDisplay(vendorSet)
-
-	// Check if we're in auto-classify mode and limit to top 5 domains
-	if d.isAutoClassifyMode(vendors) {
-		vendors = d.limitToTopDomains(vendors, 5)
+	available := 0
+	if d.options.Width > 0 {
+		available = d.options.Width - periodWidth - 11
+	}
+	cells, widths := full, columnWidths(vendors, full, 12, maxNameWidth)
+	if available > 0 && sumColumns(widths) > available {
+		nameWidth := available/len(vendors) - 2
+		if nameWidth < 8 {
+			nameWidth = 8
+		}
+		cells, widths = compact, columnWidths(vendors, compact, 5, nameWidth)
+	}
+	hidden := 0
+	for available > 0 && len(vendors) > 1 && sumColumns(widths) > available {
+		vendors, widths = vendors[:len(vendors)-1], widths[:len(widths)-1]
+		hidden++
 	}
 
 	// Header
-	out.WriteString(fmt.Sprintf("%-15s %10s", "Period", "Total"))
-	for _, vendor := range vendors {
-		out.WriteString(fmt.Sprintf("  %12s", vendor))
+	out.WriteString(fmt.Sprintf("%-*s %10s", periodWidth, "Period", "Total"))
+	for i, vendor := range vendors {
+		out.WriteString(fmt.Sprintf("  %*s", widths[i], Truncate(vendor, widths[i])))
 	}
 	out.WriteString("\n")
 
-	out.WriteString(strings.Repeat("─", 15+12+len(vendors)*14))
+	out.WriteString(strings.Repeat("─", periodWidth+11+sumColumns(widths)))
 	out.WriteString("\n")
 
 	// Render each period
-	for _, period := range d.timeline.Periods {
-		out.WriteString(fmt.Sprintf("%-15s %10s",
+	for p, period := range d.timeline.Periods {
+		out.WriteString(fmt.Sprintf("%-*s %10s",
+			periodWidth,
 			period.Period,
 			analyzer.FormatNumber(period.TotalCommits),
 		))
 
+		for i, vendor := range vendors {
+			cell := fmt.Sprintf("%*s", widths[i], cells[vendor][p])
+			if cells[vendor][p] != "-" {
+				cell = lipgloss.NewStyle().Foreground(d.colors[vendor]).Render(cell)
+			}
+			out.WriteString("  " + cell)
+		}
+		out.WriteString("\n")
+	}
+
+	if hidden > 0 {
+		out.WriteString("\n")
+		out.WriteString(dimStyle.Render(fmt.Sprintf("%d more vendors not shown; widen the terminal or use --output csv", hidden)))
+		out.WriteString("\n")
+	}
+
+	return out.String()
+}
+
+// columnWidths sizes each vendor column to its widest cell and its name,
+// within [min, max] for the name
+func columnWidths(vendors []string, cells map[string][]string, min, max int) []int {
+	widths := make([]int, len(vendors))
+	for i, vendor := range vendors {
+		width := lipgloss.Width(vendor)
+		if width > max {
+			width = max
+		}
+		if width < min {
+			width = min
+		}
+		for _, cell := range cells[vendor] {
+			if n := lipgloss.Width(cell); n > width {
+				width = n
+			}
+		}
+		widths[i] = width
+	}
+	return widths
+}
+
+// sumColumns returns the width taken by vendor columns and their separators
+func sumColumns(widths []int) int {
+	total := 0
+	for _, w := range widths {
+		total += w + 2
+	}
+	return total
+}
+
+// periodWidth returns the width of the period column: the original 15
+// characters, narrowed to the longest period on small terminals
+func (d *TimelineDisplay) periodWidth() int {
+	if d.options.Width <= 0 {
+		return 15
+	}
+	width := len("Period")
+	for _, period := range d.timeline.Periods {
+		if n := len(period.Period); n > width {
+			width = n
+		}
+	}
+	return width + 2
+}
+
+// renderShareBars renders one stacked bar per period showing each vendor's
+// share of the period's commits
+func (d *TimelineDisplay) renderShareBars() string {
+	var out strings.Builder
+
+	out.WriteString(headerStyle.Render("Vendor Share by Period"))
+	out.WriteString("\n\n")
+
+	vendors := d.displayVendors()
+	periodWidth := d.periodWidth()
+	barWidth := shareBarWidth
+	if d.options.Width > 0 && d.options.Width-periodWidth-10 < barWidth {
+		barWidth = d.options.Width - periodWidth - 10
+		if barWidth < minBarWidth {
+			barWidth = minBarWidth
+		}
+	}
+
+	for _, period := range d.timeline.Periods {
+		segments := make([]barSegment, 0, len(vendors))
 		for _, vendor := range vendors {
-			metrics, ok := period.VendorMetrics[vendor]
-			if !ok || metrics.TotalCommits == 0 {
-				out.WriteString(fmt.Sprintf("  %12s", "-"))
-				continue
+			if metrics, ok := period.VendorMetrics[vendor]; ok {
+				segments = append(segments, barSegment{value: metrics.TotalCommits, color: d.colors[vendor]})
 			}
+		}
 
-			commits := metrics.TotalCommits
-			pct := period.GetVendorPercentage(vendor, "commits")
+		out.WriteString(fmt.Sprintf("%-*s %s %8s\n",
+			periodWidth,
+			period.Period,
+			stackedBar(segments, period.TotalCommits, barWidth),
+			analyzer.FormatNumber(period.TotalCommits),
+		))
+	}
 
-			vendorStyle := lipgloss.NewStyle().Foreground(d.colors[vendor])
+	// Legend, wrapped to the terminal width
+	entries := make([]string, 0, len(vendors)+1)
+	for _, vendor := range vendors {
+		entries = append(entries, lipgloss.NewStyle().Foreground(d.colors[vendor]).Render("█ "+Truncate(vendor, maxNameWidth)))
+	}
 
-			// Format: "1,234 (45%)"
-			display := fmt.Sprintf("%s (%.0f%%)",
-				analyzer.FormatNumber(commits),
-				pct,
-			)
-			out.WriteString(fmt.Sprintf("  %s", vendorStyle.Render(fmt.Sprintf("%12s", display))))
+	indent := strings.Repeat(" ", periodWidth+1)
+	line := indent
+	out.WriteString("\n")
+	for i, entry := range entries {
+		if i > 0 && d.options.Width > 0 && lipgloss.Width(line)+2+lipgloss.Width(entry) > d.options.Width {
+			out.WriteString(line + "\n")
+			line = indent
+		} else if i > 0 {
+			line += "  "
 		}
-		out.WriteString("\n")
+		line += entry
 	}
+	out.WriteString(line + "\n")
 
 	return out.String()
 }
 
+// renderSparklines renders per-vendor sparklines of commits and contributors
+// across periods, each scaled to the vendor's own peak
+func (d *TimelineDisplay) renderSparklines() string {
+	var out strings.Builder
+
+	out.WriteString(headerStyle.Render("Vendor Trends"))
+	out.WriteString("\n\n")
+
+	// Two columns of sparkline plus "  peak N, last N" after the category
+	width := len(d.timeline.Periods)
+	if width > sparklineWidth {
+		width = sparklineWidth
+	}
+	if d.options.Width > 0 {
+		if fit := (d.options.Width - 65) / 2; fit < width {
+			width = fit
+		}
+		if width < minSparklineWidth {
+			width = minSparklineWidth
+		}
+	}
+	column := width + 22
+
+	out.WriteString(fmt.Sprintf("%-18s %-*s  %s\n", "Category", column, "Commits", "Contributors"))
+	for _, vendor := range d.displayVendors() {
+		commits := make([]int, len(d.timeline.Periods))
+		contributors := make([]int, len(d.timeline.Periods))
+		for i, period := range d.timeline.Periods {
+			if metrics, ok := period.VendorMetrics[vendor]; ok {
+				commits[i] = metrics.TotalCommits
+				contributors[i] = metrics.ContributorCount()
+			}
+		}
+
+		style := lipgloss.NewStyle().Foreground(d.colors[vendor])
+		out.WriteString(fmt.Sprintf("%s %s  %s\n",
+			style.Render(fmt.Sprintf("%-18s", Truncate(vendor, 18))),
+			padRight(style.Render(sparkline(commits, width))+dimStyle.Render(trendRange(commits)), column),
+			style.Render(sparkline(contributors, width))+dimStyle.Render(trendRange(contributors)),
+		))
+	}
+
+	return out.String()
+}
+
+// trendRange formats the peak and latest value of a series
+func trendRange(values []int) string {
+	if len(values) == 0 {
+		return ""
+	}
+	peak := 0
+	for _, v := range values {
+		if v > peak {
+			peak = v
+		}
+	}
+	return fmt.Sprintf("  peak %s, last %s", analyzer.FormatNumber(peak), analyzer.FormatNumber(values[len(values)-1]))
+}
+
+// padRight pads a styled string with spaces to a visible width
+func padRight(s string, width int) string {
+	if w := lipgloss.Width(s); w < width {
+		return s + strings.Repeat(" ", width-w)
+	}
+	return s
+}
+
+// displayVendors returns the vendors shown in the timeline sections,
+// community first then by total commits with "others" last
+func (d *TimelineDisplay) displayVendors() []string {
+	return d.getVendorsToDisplay(d.vendorSet())
+}
+
+// vendorSet returns every vendor present in any period
+func (d *TimelineDisplay) vendorSet() map[string]bool {
+	vendorSet := make(map[string]bool)
+	for _, period := range d.timeline.Periods {
+		for vendor := range period.VendorMetrics {
+			vendorSet[vendor] = true
+		}
+	}
+	return vendorSet
+}
+
 // getVendorsToDisplay returns all vendors sorted by total commits
 func (d *TimelineDisplay) getVendorsToDisplay(vendorSet map[string]bool) []string {
 	// Always show community first
@@ -179,56 +381,9 @@ func (d *TimelineDisplay) getVendorsToDisplay(vendorSet map[string]bool) []strin
 		}
 	}
 
-	// Sort by commit count (descending)
-	vendorNames := make([]string, 0, len(otherVendors))
-	for name := range otherVendors {
-		vendorNames = append(vendorNames, name)
-	}
-	sort.Slice(vendorNames, func(i, j int) bool {
-		return otherVendors[vendorNames[i]] > otherVendors[vendorNames[j]]
-	})
-
-	// Show all vendors
-	vendors = append(vendors, vendorNames...)
-	return vendors
-}
-
-// isAutoClassifyMode checks if we're in auto-classify mode (no vendor file)
-// by checking if any vendor names start with "@" (which indicates email domains)
-func (d *TimelineDisplay) isAutoClassifyMode(vendors []string) bool {
-	for _, vendor := range vendors {
-		if strings.HasPrefix(vendor, "@") {
-			return true
-		}
-	}
-	return false
-}
-
-// limitToTopDomains limits vendors to top N domains plus community
-func (d *TimelineDisplay) limitToTopDomains(vendors []string, limit int) []string {
-	result := make([]string, 0, limit+1)
-	domainCount := 0
-
-	for _, vendor := range vendors {
-		// Always include community
-		if vendor == "community" {
-			result = append(result, vendor)
-			continue
-		}
-
-		// Include domains (prefixed with @) up to the limit
-		if strings.HasPrefix(vendor, "@") {
-			if domainCount < limit {
-				result = append(result, vendor)
-				domainCount++
-			}
-		} else {
-			// Include non-domain vendors (shouldn't happen in auto-classify mode)
-			result = append(result, vendor)
-		}
-	}
-
-	return result
+	// Show all vendors, sorted by commit count (descending), then name
+	vendors = append(vendors, types.RankNames(otherVendors)...)
+	return analyzer.OthersLast(vendors)
 }
 
 // renderTrendSummary shows key trends
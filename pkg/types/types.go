@@ -1,23 +1,109 @@
 package types
 
-import "time"
+import (
+	"sort"
+	"time"
+)
 
 // CommitData represents a single commit with its metadata
 type CommitData struct {
-	SHA        string
-	AuthorName string
-	AuthorEmail string
-	Date       time.Time
-	Additions  int
-	Deletions  int
-	Message    string
+	SHA           string
+	AuthorName    string
+	AuthorEmail   string
+	AuthorCompany string // from profile data, empty when unknown
+	Date          time.Time
+	Additions     int
+	Deletions     int
+	Message       string
+	Files         []FileStat // per-file line stats
+}
+
+// FileStat holds the lines changed in one file by a commit
+type FileStat struct {
+	Path      string
+	Additions int
+	Deletions int
+}
+
+// ContributorID returns the identity key used to count a commit's author
+// (email, falling back to name when the email is missing)
+func (c *CommitData) ContributorID() string {
+	if c.AuthorEmail != "" {
+		return c.AuthorEmail
+	}
+	return c.AuthorName
 }
 
 // ContributorData represents aggregated contributor information
 type ContributorData struct {
-	Name  string
-	Email string
-	Commits int
+	Name        string
+	Email       string
+	Commits     int
+	Additions   int
+	Deletions   int
+	FirstCommit time.Time
+	LastCommit  time.Time
+}
+
+// Identity renders the contributor as "Name <email>"
+func (cd *ContributorData) Identity() string {
+	return FormatIdentity(cd.Name, cd.Email)
+}
+
+// FormatIdentity renders a name and email as "Name <email>", or whichever
+// of the two is set
+func FormatIdentity(name, email string) string {
+	switch {
+	case name == "":
+		return email
+	case email == "":
+		return name
+	default:
+		return name + " <" + email + ">"
+	}
+}
+
+// AddCommit accumulates a commit into the contributor's stats
+func (cd *ContributorData) AddCommit(commit *CommitData) {
+	if cd.Name == "" {
+		cd.Name = commit.AuthorName
+	}
+	if cd.Email == "" {
+		cd.Email = commit.AuthorEmail
+	}
+	cd.Commits++
+	cd.Additions += commit.Additions
+	cd.Deletions += commit.Deletions
+	if cd.FirstCommit.IsZero() || commit.Date.Before(cd.FirstCommit) {
+		cd.FirstCommit = commit.Date
+	}
+	if cd.LastCommit.IsZero() || commit.Date.After(cd.LastCommit) {
+		cd.LastCommit = commit.Date
+	}
+}
+
+// Merge accumulates another contributor's stats into this one
+func (cd *ContributorData) Merge(other *ContributorData) {
+	if cd.Name == "" {
+		cd.Name = other.Name
+	}
+	if cd.Email == "" {
+		cd.Email = other.Email
+	}
+	cd.Commits += other.Commits
+	cd.Additions += other.Additions
+	cd.Deletions += other.Deletions
+	if cd.FirstCommit.IsZero() || (!other.FirstCommit.IsZero() && other.FirstCommit.Before(cd.FirstCommit)) {
+		cd.FirstCommit = other.FirstCommit
+	}
+	if other.LastCommit.After(cd.LastCommit) {
+		cd.LastCommit = other.LastCommit
+	}
+}
+
+// LinesChanged returns total lines added and deleted
+func (cd *ContributorData) LinesChanged() int {
+	return cd.Additions + cd.Deletions
 }
 
 // VendorMetrics contains metrics for a specific vendor or community
@@ -26,28 +112,116 @@ type VendorMetrics struct {
 	TotalCommits       int
 	TotalAdditions     int
 	TotalDeletions     int
-	UniqueContributors map[string]bool // email -> bool
-	CommitsByMonth     map[string]int  // "YYYY-MM" -> count
+	UniqueContributors map[string]*ContributorData // contributor id -> stats
+	CommitsByMonth     map[string]int              // "YYYY-MM" -> count
 	AdditionsByMonth   map[string]int
 	DeletionsByMonth   map[string]int
+	// Children holds the brand-level metrics folded into a parent by a rollup
+	Children map[string]*VendorMetrics
 }
 
 // NewVendorMetrics creates a new VendorMetrics instance
 func NewVendorMetrics(name string) *VendorMetrics {
 	return &VendorMetrics{
 		Name:               name,
-		UniqueContributors: make(map[string]bool),
+		UniqueContributors: make(map[string]*ContributorData),
 		CommitsByMonth:     make(map[string]int),
 		AdditionsByMonth:   make(map[string]int),
 		DeletionsByMonth:   make(map[string]int),
 	}
 }
 
+// TrackContributor records a commit against its author's per-vendor stats
+func (vm *VendorMetrics) TrackContributor(commit *CommitData) {
+	id := commit.ContributorID()
+	if id == "" {
+		return
+	}
+	stats, ok := vm.UniqueContributors[id]
+	if !ok {
+		stats = &ContributorData{}
+		vm.UniqueContributors[id] = stats
+	}
+	stats.AddCommit(commit)
+}
+
+// Absorb adds another vendor's totals, monthly series and contributors
+// into this one. Contributors present in both are counted once.
+func (vm *VendorMetrics) Absorb(other *VendorMetrics) {
+	vm.TotalCommits += other.TotalCommits
+	vm.TotalAdditions += other.TotalAdditions
+	vm.TotalDeletions += other.TotalDeletions
+
+	for id, stats := range other.UniqueContributors {
+		merged, ok := vm.UniqueContributors[id]
+		if !ok {
+			merged = &ContributorData{}
+			vm.UniqueContributors[id] = merged
+		}
+		merged.Merge(stats)
+	}
+
+	for month, n := range other.CommitsByMonth {
+		vm.CommitsByMonth[month] += n
+	}
+	for month, n := range other.AdditionsByMonth {
+		vm.AdditionsByMonth[month] += n
+	}
+	for month, n := range other.DeletionsByMonth {
+		vm.DeletionsByMonth[month] += n
+	}
+}
+
 // ContributorCount returns the number of unique contributors
 func (vm *VendorMetrics) ContributorCount() int {
 	return len(vm.UniqueContributors)
 }
 
+// TopContributors returns the n most active contributors, ranked by commits
+// then lines changed. n <= 0 returns all contributors.
+func (vm *VendorMetrics) TopContributors(n int) []*ContributorData {
+	return SortContributors(vm.UniqueContributors, n)
+}
+
+// SortContributors ranks contributors by commits, then lines changed, then
+// email so that the order is stable between runs
+func SortContributors(contributors map[string]*ContributorData, n int) []*ContributorData {
+	result := make([]*ContributorData, 0, len(contributors))
+	for _, c := range contributors {
+		result = append(result, c)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Commits != result[j].Commits {
+			return result[i].Commits > result[j].Commits
+		}
+		if result[i].LinesChanged() != result[j].LinesChanged() {
+			return result[i].LinesChanged() > result[j].LinesChanged()
+		}
+		return result[i].Email < result[j].Email
+	})
+
+	if n > 0 && len(result) > n {
+		result = result[:n]
+	}
+	return result
+}
+
+// Metric returns the vendor's commits, additions, deletions or contributors
+// (commits for unknown metrics)
+func (vm *VendorMetrics) Metric(by string) int {
+	switch by {
+	case "additions":
+		return vm.TotalAdditions
+	case "deletions":
+		return vm.TotalDeletions
+	case "contributors":
+		return vm.ContributorCount()
+	default:
+		return vm.TotalCommits
+	}
+}
+
 // NetChanges returns net lines changed (additions - deletions)
 func (vm *VendorMetrics) NetChanges() int {
 	return vm.TotalAdditions - vm.TotalDeletions
@@ -108,14 +282,33 @@ func (ra *RepositoryAnalysis) GetVendorPercentage(vendorName, metric string) flo
 	}
 }
 
-// GetSortedVendors returns vendor names sorted by a metric
+// GetSortedVendors returns vendor names sorted by a metric (commits,
+// additions, deletions or contributors) in descending order, then by name;
+// "name" sorts alphabetically
 func (ra *RepositoryAnalysis) GetSortedVendors(by string) []string {
-	vendors := make([]string, 0, len(ra.VendorMetrics))
-	for name := range ra.VendorMetrics {
-		vendors = append(vendors, name)
+	values := make(map[string]int, len(ra.VendorMetrics))
+	for name, metrics := range ra.VendorMetrics {
+		values[name] = 0
+		if by != "name" {
+			values[name] = metrics.Metric(by)
+		}
 	}
+	return RankNames(values)
+}
 
-	// Sort based on metric
-	// (we'll implement a proper sort later, for now just return the list)
-	return vendors
+// RankNames returns the names of a map sorted by value in descending order,
+// then by name. It is the ordering shared by every vendor list.
+func RankNames(values map[string]int) []string {
+	names := make([]string, 0, len(values))
+	for name := range values {
+		names = append(names, name)
+	}
+
+	sort.Slice(names, func(i, j int) bool {
+		if values[names[i]] != values[names[j]] {
+			return values[names[i]] > values[names[j]]
+		}
+		return names[i] < names[j]
+	})
+	return names
 }